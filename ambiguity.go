@@ -0,0 +1,155 @@
+package genderize
+
+// DefaultUnisexThreshold default ambiguity score of unisex names.
+const DefaultUnisexThreshold = 0.5
+
+// Ambiguity of a name.
+type Ambiguity struct {
+	// Score from 0 (clear gender) to 1 (no idea), the maximum of Margin and
+	// Disagreement. Names without data score 1.
+	Score float64 `json:"score"`
+
+	// Margin ambiguity of the probability: one minus doubled distance between
+	// the probability and 0.5, or the lower bound of its interval when the
+	// analyzer widens it.
+	Margin float64 `json:"margin"`
+
+	// Disagreement of the results: doubled count-weighted share of the
+	// minority gender, 1 when results split evenly.
+	Disagreement float64 `json:"disagreement"`
+
+	// Unisex is set when the score reaches analyzer threshold.
+	Unisex bool `json:"unisex"`
+}
+
+// AmbiguityAnalyzer flags unisex names.
+type AmbiguityAnalyzer struct {
+	// Threshold score of unisex names, DefaultUnisexThreshold when zero.
+	Threshold float64
+
+	// MinCount results based on fewer rows do not count as disagreement.
+	MinCount int64
+
+	// Widen bases margin on the lower bound of the probability interval, so
+	// results with few rows are ambiguous and unisex then includes "too
+	// little data".
+	Widen bool
+
+	// Level confidence level of the probability interval, DefaultLevel
+	// when zero.
+	Level float64
+}
+
+// Analyze returns ambiguity of the name results, e.g. from different
+// countries.
+func (a AmbiguityAnalyzer) Analyze(genders ...*Gender) *Ambiguity {
+	ambiguity := &Ambiguity{
+		Score:  1,
+		Margin: 1,
+	}
+
+	var (
+		name         string
+		male, female float64
+	)
+
+	for _, g := range genders {
+		name = g.Name
+
+		if !g.Gender.IsKnown() || g.Count < a.MinCount {
+			continue
+		}
+
+		if g.Gender == Male {
+			male += float64(g.Count)
+		} else {
+			female += float64(g.Count)
+		}
+	}
+
+	// Even split has gender data and keeps the maximal margin ambiguity.
+	estimate := combine(name, genders, nil)
+	if _, ok := estimate.male(); !ok {
+		return ambiguity
+	}
+
+	p := estimate.Probability
+	if a.Widen {
+		p = estimate.WilsonInterval(a.Level).Lower
+	}
+
+	if p > 0.5 {
+		ambiguity.Margin = 1 - (2*p - 1)
+	}
+
+	if total := male + female; total > 0 {
+		minority := male
+		if female < male {
+			minority = female
+		}
+
+		ambiguity.Disagreement = 2 * minority / total
+	}
+
+	ambiguity.Score = ambiguity.Margin
+	if ambiguity.Disagreement > ambiguity.Score {
+		ambiguity.Score = ambiguity.Disagreement
+	}
+
+	threshold := a.Threshold
+	if threshold <= 0 {
+		threshold = DefaultUnisexThreshold
+	}
+
+	ambiguity.Unisex = ambiguity.Score >= threshold
+
+	return ambiguity
+}
+
+// Ambiguity returns ambiguity score of the result, see Ambiguity.Score.
+func (g *Gender) Ambiguity() float64 {
+	return AmbiguityAnalyzer{}.Analyze(g).Score
+}
+
+// IsUnisex reports whether the result is ambiguous enough for a unisex name
+// with the default analyzer.
+func (g *Gender) IsUnisex() bool {
+	return AmbiguityAnalyzer{}.Analyze(g).Unisex
+}
+
+// Unisex returns new collection of results whose names are unisex, all
+// results of the same name, e.g. from different countries, are analyzed
+// together.
+func (c *Collection) Unisex(a AmbiguityAnalyzer) *Collection {
+	byName := map[string][]*Gender{}
+	for _, g := range c.genders {
+		byName[g.Name] = append(byName[g.Name], g)
+	}
+
+	unisex := map[string]bool{}
+	for name, genders := range byName {
+		unisex[name] = a.Analyze(genders...).Unisex
+	}
+
+	return c.Filter(func(g *Gender) bool {
+		return unisex[g.Name]
+	})
+}
+
+// Ambiguity returns ambiguity of the name across all queried countries.
+func (c *Consensus) Ambiguity(name string, a AmbiguityAnalyzer) (*Ambiguity, error) {
+	breakdown, err := c.Breakdown(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var genders []*Gender
+
+	for _, g := range breakdown {
+		if g != nil {
+			genders = append(genders, g)
+		}
+	}
+
+	return a.Analyze(genders...), nil
+}
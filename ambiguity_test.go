@@ -0,0 +1,155 @@
+package genderize_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+func TestGender_Ambiguity(t *testing.T) {
+	alice := &genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.99, Count: 80000}
+	if alice.Ambiguity() > 0.05 || alice.IsUnisex() {
+		t.Errorf(`Should not be ambiguous, %f given`, alice.Ambiguity())
+	}
+
+	sasha := &genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.55, Count: 80000}
+	if sasha.Ambiguity() < 0.8 || !sasha.IsUnisex() {
+		t.Errorf(`Should be ambiguous, %f given`, sasha.Ambiguity())
+	}
+
+	zoran := &genderize.Gender{Name: "Zoran", Gender: genderize.Male, Probability: 0.99, Count: 3}
+	if zoran.Ambiguity() > 0.05 || zoran.IsUnisex() {
+		t.Errorf(`Should not be ambiguous, %f given`, zoran.Ambiguity())
+	}
+
+	zyxw := &genderize.Gender{Name: "Zyxw"}
+	if zyxw.Ambiguity() != 1 || zyxw.IsUnisex() {
+		t.Error(`Should be unknown`)
+	}
+}
+
+func TestAmbiguityAnalyzer_Analyze(t *testing.T) {
+	a := genderize.AmbiguityAnalyzer{
+		MinCount: 100,
+	}
+
+	ambiguity := a.Analyze(
+		&genderize.Gender{Name: "Andrea", Gender: genderize.Male, Probability: 0.98, Count: 3000, CountryID: "IT"},
+		&genderize.Gender{Name: "Andrea", Gender: genderize.Female, Probability: 0.97, Count: 3000, CountryID: "DE"},
+		&genderize.Gender{Name: "Andrea", Gender: genderize.Male, Probability: 1, Count: 10, CountryID: "FR"},
+	)
+
+	if ambiguity.Disagreement != 1 {
+		t.Errorf(`Should be %f, %f given`, 1.0, ambiguity.Disagreement)
+	}
+
+	if !ambiguity.Unisex {
+		t.Error(`Should be unisex`)
+	}
+
+	ambiguity = genderize.AmbiguityAnalyzer{Threshold: 0.99}.Analyze(
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.55, Count: 80000},
+	)
+
+	if ambiguity.Unisex {
+		t.Error(`Should not be unisex`)
+	}
+}
+
+func TestAmbiguityAnalyzer_Analyze_Widen(t *testing.T) {
+	a := genderize.AmbiguityAnalyzer{
+		Widen: true,
+	}
+
+	if ambiguity := a.Analyze(&genderize.Gender{Name: "Zoran", Gender: genderize.Male, Probability: 0.99, Count: 3}); !ambiguity.Unisex {
+		t.Errorf(`Should be unisex, %+v given`, ambiguity)
+	}
+
+	if ambiguity := a.Analyze(&genderize.Gender{Name: "John", Gender: genderize.Male, Probability: 0.99, Count: 80000}); ambiguity.Unisex {
+		t.Errorf(`Should not be unisex, %+v given`, ambiguity)
+	}
+}
+
+func TestAmbiguityAnalyzer_Analyze_EvenSplit(t *testing.T) {
+	ambiguity := genderize.AmbiguityAnalyzer{}.Analyze(
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.9, Count: 100, CountryID: "RU"},
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Female, Probability: 0.9, Count: 100, CountryID: "US"},
+	)
+
+	if ambiguity.Score != 1 || ambiguity.Margin != 1 || ambiguity.Disagreement != 1 || !ambiguity.Unisex {
+		t.Errorf(`Should be maximally ambiguous, %+v given`, ambiguity)
+	}
+
+	ambiguity = genderize.AmbiguityAnalyzer{}.Analyze(
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.5, Count: 100000},
+	)
+
+	if ambiguity.Score != 1 || !ambiguity.Unisex {
+		t.Errorf(`Should be maximally ambiguous, %+v given`, ambiguity)
+	}
+}
+
+func TestCollection_Unisex_EvenSplit(t *testing.T) {
+	httpClient := testCollectionClient(
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.9, Count: 100, CountryID: "RU"},
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Female, Probability: 0.9, Count: 100, CountryID: "US"},
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.99, Count: 80000, CountryID: "US"},
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Sasha", "Sasha", "Alice")
+
+	c := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		ExecuteX(r).
+		Unisex(genderize.AmbiguityAnalyzer{})
+
+	should := []string{"Sasha", "Sasha"}
+	if !reflect.DeepEqual(c.Names(), should) {
+		t.Errorf(`Should be %v, %v given`, should, c.Names())
+	}
+}
+
+func TestCollection_Unisex(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testConsensusClient()))
+
+	r := genderize.NewRequest(context.TODO()).
+		NameIn("Andrea", "IT").
+		NameIn("Andrea", "DE").
+		NameIn("Mario", "IT")
+
+	c := client.ExecuteX(r).Unisex(genderize.AmbiguityAnalyzer{})
+
+	should := []string{"Andrea", "Andrea"}
+	if !reflect.DeepEqual(c.Names(), should) {
+		t.Errorf(`Should be %v, %v given`, should, c.Names())
+	}
+}
+
+func TestConsensus_Ambiguity(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testConsensusClient()))
+
+	c, err := client.Consensus(context.TODO(), []string{"Andrea", "Mario"}, "IT", "DE")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	andrea, err := c.Ambiguity("Andrea", genderize.AmbiguityAnalyzer{})
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if !andrea.Unisex {
+		t.Error(`Should be unisex`)
+	}
+
+	mario, err := c.Ambiguity("Mario", genderize.AmbiguityAnalyzer{})
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if mario.Unisex {
+		t.Error(`Should not be unisex`)
+	}
+}
@@ -0,0 +1,152 @@
+package genderize
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// BreakerState circuit breaker state.
+type BreakerState int
+
+const (
+	// BreakerClosed requests are executed.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen requests fail fast with ErrCircuitOpen.
+	BreakerOpen
+
+	// BreakerHalfOpen single trial request is executed, the rest fail fast.
+	BreakerHalfOpen
+)
+
+// String returns "closed", "open" or "half-open".
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// BreakerListener circuit breaker state change callback.
+type BreakerListener func(from, to BreakerState)
+
+type breaker struct {
+	threshold int
+	coolDown  time.Duration
+	listener  BreakerListener
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow returns ErrCircuitOpen when request must fail fast, probe is true
+// when request is the half-open trial.
+func (b *breaker) allow() (probe bool, err error) {
+	b.mu.Lock()
+
+	from := b.state
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.coolDown {
+			b.mu.Unlock()
+
+			return false, ErrCircuitOpen
+		}
+
+		b.state = BreakerHalfOpen
+		b.probing = true
+		probe = true
+	case BreakerHalfOpen:
+		if b.probing {
+			b.mu.Unlock()
+
+			return false, ErrCircuitOpen
+		}
+
+		b.probing = true
+		probe = true
+	}
+
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+
+	return probe, nil
+}
+
+// done records result of the allowed request. Requests allowed before the
+// breaker opened may finish late: their results are ignored while the breaker
+// is open, and only the trial request decides the half-open state.
+func (b *breaker) done(probe bool, err error) {
+	b.mu.Lock()
+
+	from := b.state
+	canceled := err != nil && errors.Is(err, context.Canceled)
+
+	switch {
+	case b.state == BreakerClosed:
+		if isUpstreamFailure(err) {
+			b.failures++
+
+			if b.failures >= b.threshold {
+				b.state = BreakerOpen
+				b.openedAt = time.Now()
+			}
+		} else if !canceled {
+			b.failures = 0
+		}
+	case b.state == BreakerHalfOpen && probe:
+		b.probing = false
+
+		switch {
+		case isUpstreamFailure(err):
+			b.state = BreakerOpen
+			b.openedAt = time.Now()
+		case !canceled:
+			b.failures = 0
+			b.state = BreakerClosed
+		}
+	}
+
+	to := b.state
+	b.mu.Unlock()
+
+	b.notify(from, to)
+}
+
+func (b *breaker) current() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+func (b *breaker) notify(from, to BreakerState) {
+	if from != to && b.listener != nil {
+		b.listener(from, to)
+	}
+}
+
+// isUpstreamFailure reports whether the error means API is degraded:
+// transport failure, internal API error or broken response.
+func isUpstreamFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	var urlErr *url.Error
+
+	return errors.Is(err, ErrInternal) || errors.Is(err, ErrResponseBody) || errors.As(err, &urlErr)
+}
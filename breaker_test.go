@@ -0,0 +1,296 @@
+package genderize_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexeyco/genderize"
+)
+
+const breakerCoolDown = 50 * time.Millisecond
+
+type breakerTransition struct {
+	from genderize.BreakerState
+	to   genderize.BreakerState
+}
+
+func TestClient_Execute_CircuitBreaker(t *testing.T) {
+	var (
+		failing int32 = 1
+		calls   int32
+	)
+
+	httpClient := testClientClient(func(_ *http.Request) (res *http.Response, err error) {
+		atomic.AddInt32(&calls, 1)
+
+		if atomic.LoadInt32(&failing) == 1 {
+			return nil, testClientErr
+		}
+
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "0")
+		h.Set(genderize.HdrXRateLimitRemaining, "0")
+		h.Set(genderize.HdrXRateReset, "0")
+
+		res = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(`[{"name":"Alice"}]`)),
+		}
+
+		return
+	})
+
+	var transitions []breakerTransition
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(httpClient),
+		genderize.WithCircuitBreaker(2, breakerCoolDown),
+		genderize.WithBreakerListener(func(from, to genderize.BreakerState) {
+			transitions = append(transitions, breakerTransition{from: from, to: to})
+		}),
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Execute(r); !errors.Is(err, testClientErr) {
+			t.Errorf(`Should be testClientErr, "%v" given`, err)
+		}
+	}
+
+	if client.BreakerState() != genderize.BreakerOpen {
+		t.Errorf(`Should be %s, %s given`, genderize.BreakerOpen, client.BreakerState())
+	}
+
+	if _, err := client.Execute(r); !errors.Is(err, genderize.ErrCircuitOpen) {
+		t.Errorf(`Should be genderize.ErrCircuitOpen, "%v" given`, err)
+	}
+
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, calls)
+	}
+
+	time.Sleep(breakerCoolDown)
+	atomic.StoreInt32(&failing, 0)
+
+	if _, err := client.Execute(r); err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if client.BreakerState() != genderize.BreakerClosed {
+		t.Errorf(`Should be %s, %s given`, genderize.BreakerClosed, client.BreakerState())
+	}
+
+	should := []breakerTransition{
+		{from: genderize.BreakerClosed, to: genderize.BreakerOpen},
+		{from: genderize.BreakerOpen, to: genderize.BreakerHalfOpen},
+		{from: genderize.BreakerHalfOpen, to: genderize.BreakerClosed},
+	}
+
+	if len(transitions) != len(should) {
+		t.Fatalf(`Should be %v, %v given`, should, transitions)
+	}
+
+	for i := range should {
+		if transitions[i] != should[i] {
+			t.Errorf(`Should be %v, %v given`, should[i], transitions[i])
+		}
+	}
+}
+
+func TestClient_Execute_CircuitBreaker_HalfOpenFailure(t *testing.T) {
+	httpClient := testClientClient(func(_ *http.Request) (res *http.Response, err error) {
+		return nil, testClientErr
+	})
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(httpClient),
+		genderize.WithCircuitBreaker(1, breakerCoolDown),
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	_, _ = client.Execute(r)
+
+	time.Sleep(breakerCoolDown)
+
+	if _, err := client.Execute(r); !errors.Is(err, testClientErr) {
+		t.Errorf(`Should be testClientErr, "%v" given`, err)
+	}
+
+	if client.BreakerState() != genderize.BreakerOpen {
+		t.Errorf(`Should be %s, %s given`, genderize.BreakerOpen, client.BreakerState())
+	}
+}
+
+func TestClient_Execute_CircuitBreaker_ClientErrors(t *testing.T) {
+	httpClient := testClientClient(func(_ *http.Request) (res *http.Response, err error) {
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "0")
+		h.Set(genderize.HdrXRateLimitRemaining, "0")
+		h.Set(genderize.HdrXRateReset, "0")
+
+		res = &http.Response{
+			StatusCode: http.StatusUnauthorized,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(`{}`)),
+		}
+
+		return
+	})
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(httpClient),
+		genderize.WithCircuitBreaker(1, breakerCoolDown),
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	_, _ = client.Execute(r)
+
+	if client.BreakerState() != genderize.BreakerClosed {
+		t.Errorf(`Should be %s, %s given`, genderize.BreakerClosed, client.BreakerState())
+	}
+}
+
+func testBreakerResponse(name string) *http.Response {
+	h := http.Header{}
+	h.Set(genderize.HdrXRateLimitLimit, "0")
+	h.Set(genderize.HdrXRateLimitRemaining, "0")
+	h.Set(genderize.HdrXRateReset, "0")
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     h,
+		Body:       ioutil.NopCloser(strings.NewReader(`[{"name":"` + name + `"}]`)),
+	}
+}
+
+func TestClient_Execute_CircuitBreaker_LateSuccess(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	httpClient := testClientClient(func(r *http.Request) (res *http.Response, err error) {
+		if strings.Contains(r.URL.RawQuery, "Slow") {
+			close(started)
+			<-release
+
+			return testBreakerResponse("Slow"), nil
+		}
+
+		return nil, testClientErr
+	})
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(httpClient),
+		genderize.WithCircuitBreaker(1, breakerCoolDown),
+	)
+
+	slow := make(chan error, 1)
+
+	go func() {
+		_, err := client.Execute(genderize.NewRequest(context.TODO()).Name("Slow"))
+		slow <- err
+	}()
+
+	<-started
+
+	if _, err := client.Execute(genderize.NewRequest(context.TODO()).Name("Alice")); !errors.Is(err, testClientErr) {
+		t.Errorf(`Should be testClientErr, "%v" given`, err)
+	}
+
+	close(release)
+
+	if err := <-slow; err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if client.BreakerState() != genderize.BreakerOpen {
+		t.Errorf(`Should be %s, %s given`, genderize.BreakerOpen, client.BreakerState())
+	}
+}
+
+func TestClient_Execute_CircuitBreaker_LateFailure(t *testing.T) {
+	var (
+		slowStarted  = make(chan struct{})
+		slowRelease  = make(chan struct{})
+		probeStarted = make(chan struct{})
+		probeRelease = make(chan struct{})
+	)
+
+	httpClient := testClientClient(func(r *http.Request) (res *http.Response, err error) {
+		switch {
+		case strings.Contains(r.URL.RawQuery, "Slow"):
+			close(slowStarted)
+			<-slowRelease
+		case strings.Contains(r.URL.RawQuery, "Probe"):
+			close(probeStarted)
+			<-probeRelease
+
+			return testBreakerResponse("Probe"), nil
+		}
+
+		return nil, testClientErr
+	})
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(httpClient),
+		genderize.WithCircuitBreaker(1, breakerCoolDown),
+	)
+
+	slow := make(chan error, 1)
+
+	go func() {
+		_, err := client.Execute(genderize.NewRequest(context.TODO()).Name("Slow"))
+		slow <- err
+	}()
+
+	<-slowStarted
+
+	_, _ = client.Execute(genderize.NewRequest(context.TODO()).Name("Alice"))
+
+	time.Sleep(breakerCoolDown)
+
+	probe := make(chan error, 1)
+
+	go func() {
+		_, err := client.Execute(genderize.NewRequest(context.TODO()).Name("Probe"))
+		probe <- err
+	}()
+
+	<-probeStarted
+
+	close(slowRelease)
+
+	if err := <-slow; !errors.Is(err, testClientErr) {
+		t.Errorf(`Should be testClientErr, "%v" given`, err)
+	}
+
+	if client.BreakerState() != genderize.BreakerHalfOpen {
+		t.Errorf(`Should be %s, %s given`, genderize.BreakerHalfOpen, client.BreakerState())
+	}
+
+	if _, err := client.Execute(genderize.NewRequest(context.TODO()).Name("Alice")); !errors.Is(err, genderize.ErrCircuitOpen) {
+		t.Errorf(`Should be genderize.ErrCircuitOpen, "%v" given`, err)
+	}
+
+	close(probeRelease)
+
+	if err := <-probe; err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if client.BreakerState() != genderize.BreakerClosed {
+		t.Errorf(`Should be %s, %s given`, genderize.BreakerClosed, client.BreakerState())
+	}
+}
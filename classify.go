@@ -0,0 +1,103 @@
+package genderize
+
+// Class of result confidence.
+type Class int
+
+const (
+	// ClassUnknown API has no gender data for the name.
+	ClassUnknown Class = iota
+
+	// ClassUncertain gender is known, but does not satisfy policy, or the
+	// estimate is evenly split.
+	ClassUncertain
+
+	// ClassConfident gender satisfies policy.
+	ClassConfident
+)
+
+// String returns "unknown", "uncertain" or "confident".
+func (c Class) String() string {
+	switch c {
+	case ClassConfident:
+		return "confident"
+	case ClassUncertain:
+		return "uncertain"
+	default:
+		return "unknown"
+	}
+}
+
+// Policy of results classification. Zero thresholds are not checked.
+type Policy struct {
+	// MinProbability minimal probability of the gender.
+	MinProbability float64
+
+	// MinCount minimal number of data rows the result is based on.
+	MinCount int64
+
+	// MinLowerBound minimal lower bound of the probability interval.
+	MinLowerBound float64
+
+	// Method of the probability interval estimation.
+	Method IntervalMethod
+
+	// Level confidence level of the probability interval, DefaultLevel
+	// when zero.
+	Level float64
+}
+
+// Classify returns class of the gender info.
+func (p Policy) Classify(g *Gender) Class {
+	if !g.Gender.IsKnown() {
+		if g.EvenSplit {
+			return ClassUncertain
+		}
+
+		return ClassUnknown
+	}
+
+	if g.Probability < p.MinProbability || g.Count < p.MinCount {
+		return ClassUncertain
+	}
+
+	if p.MinLowerBound > 0 {
+		if g.Interval(p.Method, p.Level).Lower < p.MinLowerBound {
+			return ClassUncertain
+		}
+	}
+
+	return ClassConfident
+}
+
+// Classification results bucketed by class.
+type Classification struct {
+	Confident *Collection
+	Uncertain *Collection
+	Unknown   *Collection
+}
+
+// Classify buckets collection results with the policy.
+func (c *Collection) Classify(p Policy) *Classification {
+	classification := &Classification{
+		Confident: c.with(nil),
+		Uncertain: c.with(nil),
+		Unknown:   c.with(nil),
+	}
+
+	for _, g := range c.genders {
+		var bucket *Collection
+
+		switch p.Classify(g) {
+		case ClassConfident:
+			bucket = classification.Confident
+		case ClassUncertain:
+			bucket = classification.Uncertain
+		default:
+			bucket = classification.Unknown
+		}
+
+		bucket.genders = append(bucket.genders, g)
+	}
+
+	return classification
+}
@@ -0,0 +1,105 @@
+package genderize_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+type classifyTableRow struct {
+	gender *genderize.Gender
+	class  genderize.Class
+}
+
+func TestPolicy_Classify(t *testing.T) {
+	p := genderize.Policy{
+		MinProbability: 0.8,
+		MinCount:       10,
+		MinLowerBound:  0.7,
+	}
+
+	table := []classifyTableRow{
+		{
+			gender: &genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.99, Count: 80000},
+			class:  genderize.ClassConfident,
+		},
+		{
+			gender: &genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.6, Count: 80000},
+			class:  genderize.ClassUncertain,
+		},
+		{
+			gender: &genderize.Gender{Name: "Zora", Gender: genderize.Female, Probability: 1, Count: 3},
+			class:  genderize.ClassUncertain,
+		},
+		{
+			gender: &genderize.Gender{Name: "Ziva", Gender: genderize.Female, Probability: 0.9, Count: 12},
+			class:  genderize.ClassUncertain,
+		},
+		{
+			gender: &genderize.Gender{Name: "Zyxw"},
+			class:  genderize.ClassUnknown,
+		},
+	}
+
+	for _, r := range table {
+		if c := p.Classify(r.gender); c != r.class {
+			t.Errorf(`%s should be %s, %s given`, r.gender.Name, r.class, c)
+		}
+	}
+}
+
+func TestCollection_Classify(t *testing.T) {
+	httpClient := testCollectionClient(
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.99, Count: 80000},
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.6, Count: 80000},
+		&genderize.Gender{Name: "Zyxw"},
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice", "Sasha", "Zyxw")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	classification := c.Classify(genderize.Policy{
+		MinProbability: 0.8,
+	})
+
+	if g := classification.Confident.FirstX(); g.Name != "Alice" {
+		t.Errorf(`Should be "%s", "%s" given`, "Alice", g.Name)
+	}
+
+	if g := classification.Uncertain.FirstX(); g.Name != "Sasha" {
+		t.Errorf(`Should be "%s", "%s" given`, "Sasha", g.Name)
+	}
+
+	if g := classification.Unknown.FirstX(); g.Name != "Zyxw" {
+		t.Errorf(`Should be "%s", "%s" given`, "Zyxw", g.Name)
+	}
+
+	if classification.Confident.Limit() != c.Limit() {
+		t.Errorf(`Should be %d, %d given`, c.Limit(), classification.Confident.Limit())
+	}
+}
+
+func TestPolicy_Classify_Credible(t *testing.T) {
+	g := &genderize.Gender{Name: "Zora", Gender: genderize.Female, Probability: 1, Count: 5}
+
+	p := genderize.Policy{
+		MinLowerBound: 0.6,
+	}
+
+	if c := p.Classify(g); c != genderize.ClassUncertain {
+		t.Errorf(`Should be %s, %s given`, genderize.ClassUncertain, c)
+	}
+
+	p.Method = genderize.IntervalCredible
+
+	if c := p.Classify(g); c != genderize.ClassConfident {
+		t.Errorf(`Should be %s, %s given`, genderize.ClassConfident, c)
+	}
+}
@@ -1,18 +1,25 @@
 package genderize
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 )
 
 // Info API rate limits info.
 type Info struct {
-	Limit     int64
-	Remaining int64
-	Reset     time.Duration
+	Limit     int64         `json:"limit"`
+	Remaining int64         `json:"remaining"`
+	Reset     time.Duration `json:"reset"`
+
+	// Unknown is set when rate limit headers are missing or malformed and
+	// client is lenient, Limit and Remaining are zero then.
+	Unknown bool `json:"unknown,omitempty"`
 }
 
 // Error response error.
@@ -23,13 +30,111 @@ type Error struct {
 // Client genderize API client.
 type Client struct {
 	options *Options
+	breaker *breaker
+	hedger  *hedger
+
+	// resetUnit unit of the rate limits reset headers.
+	resetUnit time.Duration
+
+	mu     sync.Mutex
+	info   *Info
+	infoAt time.Time
 }
 
-// Execute executes API request and returns result.
+// Execute executes API request and returns result. Names with different
+// country IDs are queried separately and merged back in request order.
+// Country-specific results are replaced with global ones according to the
+// fallback policy, if any. Response with a number of results other than
+// the number of names fails with ErrResponseBody.
 func (c *Client) Execute(request *Request) (collection *Collection, err error) {
+	if collection, err = c.executeSplit(request); err != nil || c.options.Fallback == nil {
+		return
+	}
+
+	c.fallback(request.ctx, collection)
+
+	return
+}
+
+func (c *Client) executeSplit(request *Request) (collection *Collection, err error) {
+	requests, positions := request.split()
+
+	switch len(requests) {
+	case 0:
+		return c.execute(request)
+	case 1:
+		return c.execute(requests[0])
+	}
+
+	var total int
+	for _, p := range positions {
+		total += len(p)
+	}
+
+	genders := make([]*Gender, total)
+	collection = &Collection{}
+
+	for i, r := range requests {
+		var sub *Collection
+		if sub, err = c.execute(r); err != nil {
+			return
+		}
+
+		if len(sub.genders) != len(positions[i]) {
+			return nil, fmt.Errorf(`%w: %d results for %d names`, ErrResponseBody, len(sub.genders), len(positions[i]))
+		}
+
+		collection.info = sub.info
+
+		for j, g := range sub.genders {
+			genders[positions[i][j]] = g
+		}
+	}
+
+	collection.genders = genders
+
+	return
+}
+
+func (c *Client) execute(request *Request) (collection *Collection, err error) {
 	u := request.Encode(c.options.APIKey)
 
-	req, err := http.NewRequestWithContext(request.ctx, http.MethodGet, u, nil)
+	c.logf("GET %s", u)
+
+	defer func() {
+		if err != nil {
+			err = RedactError(err, c.options.APIKey)
+			c.logf("GET %s: %s", u, err)
+		}
+	}()
+
+	if c.breaker != nil {
+		var probe bool
+		if probe, err = c.breaker.allow(); err != nil {
+			return
+		}
+
+		defer func() {
+			c.breaker.done(probe, err)
+		}()
+	}
+
+	if c.hedger != nil {
+		collection, err = c.hedge(request.ctx, u, request.length())
+	} else {
+		collection, err = c.do(request.ctx, u)
+	}
+
+	if err == nil && collection.Length() != request.length() {
+		err = fmt.Errorf(`%w: %d results for %d names`, ErrResponseBody, collection.Length(), request.length())
+		collection = nil
+	}
+
+	return
+}
+
+func (c *Client) do(ctx context.Context, u string) (collection *Collection, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return
 	}
@@ -48,6 +153,30 @@ func (c *Client) Execute(request *Request) (collection *Collection, err error) {
 	return
 }
 
+// Info returns rate limits info of the last API response, nil when there
+// were no responses yet.
+func (c *Client) Info() *Info {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.info == nil {
+		return nil
+	}
+
+	info := *c.info
+
+	return &info
+}
+
+// BreakerState returns circuit breaker state, BreakerClosed when it is disabled.
+func (c *Client) BreakerState() BreakerState {
+	if c.breaker == nil {
+		return BreakerClosed
+	}
+
+	return c.breaker.current()
+}
+
 // ExecuteX like Execute, but panics when error.
 func (c *Client) ExecuteX(request *Request) *Collection {
 	collection, err := c.Execute(request)
@@ -58,6 +187,43 @@ func (c *Client) ExecuteX(request *Request) *Collection {
 	return collection
 }
 
+// quotaWait returns duration to wait until the current time window has room
+// for the names, zero when it has or rate limits are unknown.
+func (c *Client) quotaWait(names int) time.Duration {
+	info := c.currentInfo()
+	if info == nil || info.Unknown || info.Remaining >= int64(names) {
+		return 0
+	}
+
+	return info.Reset
+}
+
+// currentInfo returns the last rate limits info as of now: reset is counted
+// from the response and the time window opened since then has the whole limit.
+func (c *Client) currentInfo() *Info {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.info == nil {
+		return nil
+	}
+
+	info := *c.info
+
+	if info.Reset -= time.Since(c.infoAt); info.Reset <= 0 {
+		info.Reset = 0
+		info.Remaining = info.Limit
+	}
+
+	return &info
+}
+
+func (c *Client) logf(format string, v ...interface{}) {
+	if c.options.Logger != nil {
+		c.options.Logger.Printf("%s", Redact(fmt.Sprintf(format, v...), c.options.APIKey))
+	}
+}
+
 func (c *Client) processAPIResponse(res *http.Response) (collection *Collection, err error) {
 	collection = &Collection{}
 
@@ -66,21 +232,42 @@ func (c *Client) processAPIResponse(res *http.Response) (collection *Collection,
 		return
 	}
 
-	switch res.StatusCode {
-	case http.StatusOK:
+	c.mu.Lock()
+	c.info = collection.info
+	c.infoAt = time.Now()
+	c.mu.Unlock()
+
+	if res.StatusCode == http.StatusOK {
 		collection.genders, err = c.processResponse(res)
+
+		return
+	}
+
+	var e error
+
+	switch res.StatusCode {
 	case http.StatusUnauthorized:
-		err = ErrInvalidAPIKey
+		e = ErrInvalidAPIKey
 	case http.StatusPaymentRequired:
-		err = ErrSubscriptionIsNotActive
+		e = ErrSubscriptionIsNotActive
 	case http.StatusUnprocessableEntity:
-		err = fmt.Errorf(`%w cause %s`, ErrValidation, c.processError(res))
+		e = ErrValidation
 	case http.StatusTooManyRequests:
-		err = fmt.Errorf(`%w cause %s`, ErrTooManyRequests, c.processError(res))
+		e = ErrTooManyRequests
 	default:
-		err = ErrInternal
+		e = ErrInternal
 	}
 
+	apiErr := newAPIError(res.StatusCode, c.processError(res), collection.info, e)
+	if e == ErrTooManyRequests && !errors.Is(apiErr, ErrRequestLimitTooLow) {
+		var ok bool
+		if apiErr.RetryAfter, ok = c.processRetryAfter(res); !ok {
+			apiErr.RetryAfter = collection.info.Reset
+		}
+	}
+
+	err = apiErr
+
 	return
 }
 
@@ -96,24 +283,59 @@ func (c *Client) processError(res *http.Response) (s string) {
 func (c *Client) processInfo(res *http.Response) (info *Info, err error) {
 	i := &Info{}
 
-	if i.Limit, err = c.processHeader(res, HdrXRateLimitLimit); err != nil {
-		err = fmt.Errorf(`%w %s`, ErrResponseHeader, err)
+	if i.Limit, err = c.processHeader(res, HdrXRateLimitLimit); err == nil {
+		i.Remaining, err = c.processHeader(res, HdrXRateLimitRemaining)
+	}
+
+	if err != nil {
+		if !c.options.LenientHeaders {
+			err = fmt.Errorf(`%w %s`, ErrResponseHeader, err)
 
-		return
+			return
+		}
+
+		err = nil
+		i = &Info{
+			Unknown: true,
+		}
 	}
 
-	if i.Remaining, err = c.processHeader(res, HdrXRateLimitRemaining); err != nil {
+	i.Reset, _ = c.processReset(res)
+	info = i
+
+	return
+}
+
+func (c *Client) processReset(res *http.Response) (d time.Duration, ok bool) {
+	for _, header := range []string{HdrXRateReset, HdrXRateLimitReset, HdrXRateLimitResetCompact} {
+		if reset, err := c.processHeader(res, header); err == nil {
+			return time.Duration(reset) * c.resetUnit, true
+		}
+	}
+
+	return c.processRetryAfter(res)
+}
+
+func (c *Client) processRetryAfter(res *http.Response) (d time.Duration, ok bool) {
+	v := res.Header.Get(HdrRetryAfter)
+	if v == "" {
 		return
 	}
 
-	reset, err := c.processHeader(res, HdrXRateReset)
-	if err == nil {
-		i.Reset = time.Duration(reset) * time.Second
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Duration(seconds) * c.resetUnit, true
 	}
 
-	info = i
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return
+	}
 
-	return
+	if d = time.Until(t); d < 0 {
+		d = 0
+	}
+
+	return d, true
 }
 
 func (c *Client) processHeader(res *http.Response, header string) (value int64, err error) {
@@ -127,18 +349,9 @@ func (c *Client) processHeader(res *http.Response, header string) (value int64,
 	return
 }
 
-func (c *Client) processResponse(res *http.Response) (genders map[string]*Gender, err error) {
-	var apiRes []*Gender
-	if err = json.NewDecoder(res.Body).Decode(&apiRes); err != nil {
+func (c *Client) processResponse(res *http.Response) (genders []*Gender, err error) {
+	if err = json.NewDecoder(res.Body).Decode(&genders); err != nil {
 		err = fmt.Errorf("%w: %s", ErrResponseBody, err)
-
-		return
-	}
-
-	genders = map[string]*Gender{}
-
-	for _, g := range apiRes {
-		genders[g.Name] = g
 	}
 
 	return
@@ -148,13 +361,31 @@ func (c *Client) processResponse(res *http.Response) (genders map[string]*Gender
 func NewClient(options ...Option) *Client {
 	client := &Client{
 		options: &Options{
-			HTTPClient: http.DefaultClient,
+			HTTPClient:   http.DefaultClient,
+			StreamLinger: defaultStreamLinger,
 		},
+		resetUnit: time.Second,
 	}
 
 	for _, opt := range options {
 		opt(client.options)
 	}
 
+	if client.options.Hedge {
+		client.hedger = &hedger{
+			delay:   client.options.HedgeDelay,
+			ratio:   client.options.HedgeRatio,
+			reserve: client.options.HedgeReserve,
+		}
+	}
+
+	if client.options.BreakerThreshold > 0 {
+		client.breaker = &breaker{
+			threshold: client.options.BreakerThreshold,
+			coolDown:  client.options.BreakerCoolDown,
+			listener:  client.options.BreakerListener,
+		}
+	}
+
 	return client
 }
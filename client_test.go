@@ -1,12 +1,15 @@
 package genderize_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/alexeyco/genderize"
 )
@@ -181,7 +184,7 @@ func TestClient_ExecuteX(t *testing.T) {
 		res = &http.Response{
 			StatusCode: http.StatusOK,
 			Header:     h,
-			Body:       ioutil.NopCloser(strings.NewReader(`[]`)),
+			Body:       ioutil.NopCloser(strings.NewReader(`[{"name":"Alice"},{"name":"John"}]`)),
 		}
 
 		return
@@ -217,3 +220,315 @@ func TestClient_ExecuteX_Panic(t *testing.T) {
 	_ = genderize.NewClient(genderize.WithHTTPClient(httpClient)).
 		ExecuteX(r)
 }
+
+func TestClient_Execute_NameIn(t *testing.T) {
+	httpClient := testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		query := req.URL.Query()
+		countryID := query.Get("country_id")
+
+		genders := make([]*genderize.Gender, 0)
+
+		for _, name := range query["name[]"] {
+			genders = append(genders, &genderize.Gender{
+				Name:        name,
+				Gender:      genderize.Female,
+				Probability: 0.9,
+				Count:       100,
+				CountryID:   countryID,
+			})
+		}
+
+		b, _ := json.Marshal(genders)
+
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "100")
+		h.Set(genderize.HdrXRateLimitRemaining, "50")
+		h.Set(genderize.HdrXRateReset, "10")
+
+		res = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}
+
+		return
+	})
+
+	r := genderize.NewRequest(context.TODO()).
+		NameIn("Andrea", "IT").
+		Name("Alice").
+		NameIn("Jean", "FR").
+		NameIn("Mario", "IT").
+		CountryID("US")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	should := [][2]string{
+		{"Andrea", "IT"},
+		{"Alice", "US"},
+		{"Jean", "FR"},
+		{"Mario", "IT"},
+	}
+
+	if c.Length() != len(should) {
+		t.Errorf(`Should be %d, %d given`, len(should), c.Length())
+	}
+
+	for i, s := range should {
+		g := c.AtX(i)
+		if g.Name != s[0] || g.CountryID != s[1] {
+			t.Errorf(`Should be %s (%s), %s (%s) given`, s[0], s[1], g.Name, g.CountryID)
+		}
+	}
+
+	if c.LimitRemaining() != 50 {
+		t.Errorf(`Should be %d, %d given`, 50, c.LimitRemaining())
+	}
+}
+
+func TestClient_Execute_NameIn_SingleCountry(t *testing.T) {
+	var countryID string
+
+	httpClient := testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		countryID = req.URL.Query().Get("country_id")
+
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "0")
+		h.Set(genderize.HdrXRateLimitRemaining, "0")
+		h.Set(genderize.HdrXRateReset, "0")
+
+		res = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(`[{"name":"Andrea"}]`)),
+		}
+
+		return
+	})
+
+	r := genderize.NewRequest(context.TODO()).
+		NameIn("Andrea", "IT")
+
+	_ = genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		ExecuteX(r)
+
+	if countryID != "IT" {
+		t.Errorf(`Should be "%s", "%s" given`, "IT", countryID)
+	}
+}
+
+func TestClient_Execute_ShortResponse(t *testing.T) {
+	httpClient := testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		names := req.URL.Query()["name[]"]
+
+		// The last name of every request is lost.
+		genders := make([]*genderize.Gender, 0)
+		for _, name := range names[:len(names)-1] {
+			genders = append(genders, &genderize.Gender{Name: name})
+		}
+
+		b, _ := json.Marshal(genders)
+
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "100")
+		h.Set(genderize.HdrXRateLimitRemaining, "50")
+		h.Set(genderize.HdrXRateReset, "10")
+
+		res = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}
+
+		return
+	})
+
+	client := genderize.NewClient(genderize.WithHTTPClient(httpClient))
+
+	requests := []*genderize.Request{
+		genderize.NewRequest(context.TODO()).
+			Name("Alice", "John"),
+		genderize.NewRequest(context.TODO()).
+			NameIn("Andrea", "IT").
+			Name("Alice", "John"),
+	}
+
+	for _, r := range requests {
+		if _, err := client.Execute(r); !errors.Is(err, genderize.ErrResponseBody) {
+			t.Errorf(`Should be genderize.ErrResponseBody, "%v" given`, err)
+		}
+	}
+}
+
+func TestClient_Execute_APIError(t *testing.T) {
+	httpClient := testClientClient(func(_ *http.Request) (res *http.Response, err error) {
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "1000")
+		h.Set(genderize.HdrXRateLimitRemaining, "0")
+		h.Set(genderize.HdrXRateReset, "60")
+
+		res = &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"error":"Request limit reached"}`)),
+		}
+
+		return
+	})
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice", "John")
+
+	_, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+
+	var apiErr *genderize.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf(`Should be *genderize.APIError, "%v" given`, err)
+	}
+
+	if !errors.Is(err, genderize.ErrTooManyRequests) {
+		t.Error(`Should be genderize.ErrTooManyRequests`)
+	}
+
+	if apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf(`Should be %d, %d given`, http.StatusTooManyRequests, apiErr.StatusCode)
+	}
+
+	if apiErr.Message != "Request limit reached" {
+		t.Errorf(`Should be "%s", "%s" given`, "Request limit reached", apiErr.Message)
+	}
+
+	if apiErr.Info == nil || apiErr.Info.Limit != 1000 {
+		t.Error(`Should contain rate limits info`)
+	}
+
+	if apiErr.RetryAfter != time.Minute {
+		t.Errorf(`Should be %s, %s given`, time.Minute, apiErr.RetryAfter)
+	}
+
+	should := "too many requests cause Request limit reached"
+	if err.Error() != should {
+		t.Errorf(`Should be "%s", "%s" given`, should, err.Error())
+	}
+}
+
+func testClientHeaders(status int, h http.Header) *http.Client {
+	return testClientClient(func(_ *http.Request) (res *http.Response, err error) {
+		body := `[{"name":"Alice","gender":"female","probability":0.9,"count":100}]`
+		if status != http.StatusOK {
+			body = `{"error":"Request limit reached"}`
+		}
+
+		res = &http.Response{
+			StatusCode: status,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+
+		return
+	})
+}
+
+func TestClient_Execute_ErrResponseHeader(t *testing.T) {
+	h := http.Header{}
+	h.Set(genderize.HdrXRateLimitLimit, "1000")
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	_, err := genderize.NewClient(genderize.WithHTTPClient(testClientHeaders(http.StatusOK, h))).
+		Execute(r)
+	if !errors.Is(err, genderize.ErrResponseHeader) {
+		t.Errorf(`Should be genderize.ErrResponseHeader, "%v" given`, err)
+	}
+}
+
+func TestClient_Execute_LenientHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set(genderize.HdrXRateLimitLimit, "n/a")
+	h.Set(genderize.HdrXRateLimitResetCompact, "30")
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	c, err := genderize.NewClient(
+		genderize.WithHTTPClient(testClientHeaders(http.StatusOK, h)),
+		genderize.WithLenientHeaders(),
+	).Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if c.LimitKnown() {
+		t.Error(`Should not be known`)
+	}
+
+	if c.LimitReset() != 30*time.Second {
+		t.Errorf(`Should be %s, %s given`, 30*time.Second, c.LimitReset())
+	}
+
+	if c.FirstX().Name != "Alice" {
+		t.Errorf(`Should be "%s", "%s" given`, "Alice", c.FirstX().Name)
+	}
+}
+
+func TestClient_Execute_ResetHeaders(t *testing.T) {
+	table := map[string]string{
+		genderize.HdrXRateReset:             "10",
+		genderize.HdrXRateLimitReset:        "10",
+		genderize.HdrXRateLimitResetCompact: "10",
+		genderize.HdrRetryAfter:             "10",
+	}
+
+	for header, value := range table {
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "1000")
+		h.Set(genderize.HdrXRateLimitRemaining, "999")
+		h.Set(header, value)
+
+		r := genderize.NewRequest(context.TODO()).
+			Name("Alice")
+
+		c, err := genderize.NewClient(genderize.WithHTTPClient(testClientHeaders(http.StatusOK, h))).
+			Execute(r)
+		if err != nil {
+			t.Errorf(`Should be nil, "%s" given`, err)
+		}
+
+		if !c.LimitKnown() {
+			t.Error(`Should be known`)
+		}
+
+		if c.LimitReset() != 10*time.Second {
+			t.Errorf(`%s: should be %s, %s given`, header, 10*time.Second, c.LimitReset())
+		}
+	}
+}
+
+func TestClient_Execute_RetryAfter(t *testing.T) {
+	h := http.Header{}
+	h.Set(genderize.HdrXRateLimitLimit, "1000")
+	h.Set(genderize.HdrXRateLimitRemaining, "0")
+	h.Set(genderize.HdrXRateReset, "3600")
+	h.Set(genderize.HdrRetryAfter, "120")
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	_, err := genderize.NewClient(genderize.WithHTTPClient(testClientHeaders(http.StatusTooManyRequests, h))).
+		Execute(r)
+
+	var apiErr *genderize.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf(`Should be *genderize.APIError, "%v" given`, err)
+	}
+
+	if apiErr.RetryAfter != 2*time.Minute {
+		t.Errorf(`Should be %s, %s given`, 2*time.Minute, apiErr.RetryAfter)
+	}
+}
@@ -1,19 +1,86 @@
 package genderize
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Gender type.
 type Gender struct {
-	Name        string  `json:"name,omitempty"`
-	Gender      string  `json:"gender,omitempty"`
-	Probability float64 `json:"probability,omitempty"`
-	Count       int64   `json:"count,omitempty"`
+	Name        string  `json:"name"`
+	Gender      Sex     `json:"gender"`
+	Probability float64 `json:"probability"`
+	Count       int64   `json:"count"`
+	CountryID   string  `json:"country_id,omitempty"`
+
+	// Fallback is set when country-specific data was too thin and the result
+	// is taken from the global dataset, CountryID is the requested one then.
+	Fallback bool `json:"fallback,omitempty"`
+
+	// EvenSplit is set on combined estimates whose known results split evenly:
+	// Gender is unknown then, Probability is 0.5 and Count is not zero.
+	EvenSplit bool `json:"even_split,omitempty"`
 }
 
-// Collection of genders.
+func (g *Gender) match(name string, countryID []string) bool {
+	if g.Name != name {
+		return false
+	}
+
+	return len(countryID) == 0 || g.CountryID == countryID[0]
+}
+
+// male returns probability the name is male, false when there is no gender
+// data.
+func (g *Gender) male() (p float64, ok bool) {
+	switch {
+	case g.Gender == Male:
+		return g.Probability, true
+	case g.Gender == Female:
+		return 1 - g.Probability, true
+	case g.EvenSplit:
+		return 0.5, true
+	default:
+		return 0, false
+	}
+}
+
+// Collection of genders. Results are kept in the order the API returned them,
+// which is the order names were added to the request, duplicates included.
 type Collection struct {
 	info    *Info
-	genders map[string]*Gender
+	genders []*Gender
+}
+
+type collectionJSON struct {
+	Info    *Info     `json:"info"`
+	Genders []*Gender `json:"genders"`
+}
+
+// MarshalJSON encodes collection with rate limits info.
+func (c *Collection) MarshalJSON() ([]byte, error) {
+	genders := c.genders
+	if genders == nil {
+		genders = []*Gender{}
+	}
+
+	return json.Marshal(&collectionJSON{
+		Info:    c.info,
+		Genders: genders,
+	})
+}
+
+// UnmarshalJSON decodes collection encoded with MarshalJSON.
+func (c *Collection) UnmarshalJSON(b []byte) (err error) {
+	var v collectionJSON
+	if err = json.Unmarshal(b, &v); err != nil {
+		return
+	}
+
+	c.info = v.Info
+	c.genders = v.Genders
+
+	return
 }
 
 // Limit returns the amount of names available in the current time window.
@@ -43,24 +110,32 @@ func (c *Collection) LimitReset() (d time.Duration) {
 	return
 }
 
+// LimitKnown reports whether rate limits info was returned by API.
+func (c *Collection) LimitKnown() bool {
+	return c.info != nil && !c.info.Unknown
+}
+
 // Length of collection.
 func (c *Collection) Length() int {
 	return len(c.genders)
 }
 
-// Find gender info by name.
-func (c *Collection) Find(name string) (g *Gender, err error) {
-	var ok bool
-	if g, ok = c.genders[name]; !ok {
+// At returns gender info by its position in the request.
+func (c *Collection) At(i int) (g *Gender, err error) {
+	if i < 0 || i >= len(c.genders) {
 		err = ErrNothingFound
+
+		return
 	}
 
+	g = c.genders[i]
+
 	return
 }
 
-// FindX like Find, but panics when error.
-func (c *Collection) FindX(name string) *Gender {
-	g, err := c.Find(name)
+// AtX like At, but panics when error.
+func (c *Collection) AtX(i int) *Gender {
+	g, err := c.At(i)
 	if err != nil {
 		panic(err)
 	}
@@ -68,10 +143,16 @@ func (c *Collection) FindX(name string) *Gender {
 	return g
 }
 
-// First gender of collection.
-func (c *Collection) First() (g *Gender, err error) {
-	for _, g = range c.genders {
-		return
+// Find gender info by name. When the name was requested several times,
+// the first result is returned. Pass country ID to look for country-specific
+// result, empty country ID stands for global one.
+func (c *Collection) Find(name string, countryID ...string) (g *Gender, err error) {
+	for _, v := range c.genders {
+		if v.match(name, countryID) {
+			g = v
+
+			return
+		}
 	}
 
 	err = ErrNothingFound
@@ -79,6 +160,33 @@ func (c *Collection) First() (g *Gender, err error) {
 	return
 }
 
+// FindX like Find, but panics when error.
+func (c *Collection) FindX(name string, countryID ...string) *Gender {
+	g, err := c.Find(name, countryID...)
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+// FindAll returns every gender info with the given name and, optionally,
+// country ID.
+func (c *Collection) FindAll(name string, countryID ...string) (genders []*Gender) {
+	for _, g := range c.genders {
+		if g.match(name, countryID) {
+			genders = append(genders, g)
+		}
+	}
+
+	return
+}
+
+// First gender of collection.
+func (c *Collection) First() (g *Gender, err error) {
+	return c.At(0)
+}
+
 // FirstX like First, but panics when error.
 func (c *Collection) FirstX() *Gender {
 	g, err := c.First()
@@ -89,10 +197,25 @@ func (c *Collection) FirstX() *Gender {
 	return g
 }
 
+// Unknown returns names API has no gender data for.
+func (c *Collection) Unknown() *Collection {
+	return c.Filter(func(g *Gender) bool {
+		_, ok := g.male()
+
+		return !ok
+	})
+}
+
+// UnknownNames returns the list of names API has no gender data for.
+func (c *Collection) UnknownNames() []string {
+	return c.Unknown().Names()
+}
+
 // CollectionEachCallback iteration callback.
 type CollectionEachCallback func(g *Gender)
 
-// Each iterate over collection.
+// Each iterate over collection in request order. Use Walk to break
+// iteration or return an error from the callback.
 func (c *Collection) Each(fn CollectionEachCallback) error {
 	if c.Length() == 0 {
 		return ErrNothingFound
@@ -30,6 +30,28 @@ var testCollectionGenders = []*genderize.Gender{
 	},
 }
 
+// nolint:gochecknoglobals,golint,stylecheck
+var testCollectionDuplicates = []*genderize.Gender{
+	{
+		Name:        "Alice",
+		Gender:      "female",
+		Probability: 0.9,
+		Count:       12345,
+	},
+	{
+		Name:        "John",
+		Gender:      "male",
+		Probability: 0.9,
+		Count:       87890,
+	},
+	{
+		Name:        "Alice",
+		Gender:      "female",
+		Probability: 0.9,
+		Count:       12345,
+	},
+}
+
 type testCollectionRoundTripper struct {
 	genders []*genderize.Gender
 }
@@ -165,6 +187,95 @@ func TestCollection_Find(t *testing.T) {
 	}
 }
 
+func TestCollection_Find_Duplicates(t *testing.T) {
+	httpClient := testCollectionClient(testCollectionDuplicates...)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice", "John", "Alice")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if c.Length() != 3 {
+		t.Errorf(`Should be %d, %d given`, 3, c.Length())
+	}
+
+	alice, err := c.Find("Alice")
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if !reflect.DeepEqual(alice, testCollectionDuplicates[0]) {
+		t.Error(`Should be equal`)
+	}
+
+	all := c.FindAll("Alice")
+	if len(all) != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, len(all))
+	}
+}
+
+func TestCollection_At(t *testing.T) {
+	httpClient := testCollectionClient(testCollectionDuplicates...)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice", "John", "Alice")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	for i, should := range testCollectionDuplicates {
+		given, err := c.At(i)
+		if err != nil {
+			t.Errorf(`Should be nil, "%s" given`, err)
+		}
+
+		if !reflect.DeepEqual(given, should) {
+			t.Errorf(`Should be equal to gender #%d`, i)
+		}
+	}
+
+	if _, err := c.At(3); !errors.Is(err, genderize.ErrNothingFound) {
+		t.Error(`Should be "genderize.ErrNothingFound"`)
+	}
+
+	if _, err := c.At(-1); !errors.Is(err, genderize.ErrNothingFound) {
+		t.Error(`Should be "genderize.ErrNothingFound"`)
+	}
+}
+
+func TestCollection_AtX_Panic(t *testing.T) {
+	httpClient := testCollectionClient()
+
+	// No names, so the response is empty.
+	r := genderize.NewRequest(context.TODO())
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	defer func() {
+		err := recover()
+		if err == nil {
+			t.Error(`Should not be nil`)
+		}
+
+		if !errors.Is(err.(error), genderize.ErrNothingFound) {
+			t.Error(`Should not be genderize.ErrNothingFound`)
+		}
+	}()
+
+	_ = c.AtX(0)
+}
+
 func TestCollection_FindX(t *testing.T) {
 	httpClient := testCollectionClient(testCollectionGenders...)
 
@@ -187,8 +298,8 @@ func TestCollection_FindX(t *testing.T) {
 func TestCollection_FindX_Panic(t *testing.T) {
 	httpClient := testCollectionClient()
 
-	r := genderize.NewRequest(context.TODO()).
-		Name("Alice", "John")
+	// No names, so the response is empty.
+	r := genderize.NewRequest(context.TODO())
 
 	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
 		Execute(r)
@@ -230,8 +341,8 @@ func TestCollection_First(t *testing.T) {
 		t.Errorf(`Should be nil, "%s" given`, err)
 	}
 
-	if !reflect.DeepEqual(person, testCollectionGenders[0]) && !reflect.DeepEqual(person, testCollectionGenders[1]) {
-		t.Error(`Should be one of two genders`)
+	if !reflect.DeepEqual(person, testCollectionGenders[0]) {
+		t.Error(`Should be equal`)
 	}
 }
 
@@ -249,16 +360,16 @@ func TestCollection_FirstX(t *testing.T) {
 
 	person := c.FirstX()
 
-	if !reflect.DeepEqual(person, testCollectionGenders[0]) && !reflect.DeepEqual(person, testCollectionGenders[1]) {
-		t.Error(`Should be one of two genders`)
+	if !reflect.DeepEqual(person, testCollectionGenders[0]) {
+		t.Error(`Should be equal`)
 	}
 }
 
 func TestCollection_FirstX_Panic(t *testing.T) {
 	httpClient := testCollectionClient()
 
-	r := genderize.NewRequest(context.TODO()).
-		Name("Alice", "John")
+	// No names, so the response is empty.
+	r := genderize.NewRequest(context.TODO())
 
 	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
 		Execute(r)
@@ -297,8 +408,8 @@ func TestCollection_Each(t *testing.T) {
 
 	cnt := 0
 	err = c.Each(func(g *genderize.Gender) {
-		if !reflect.DeepEqual(g, testCollectionGenders[0]) && !reflect.DeepEqual(g, testCollectionGenders[1]) {
-			t.Error(`Should be one of two genders`)
+		if !reflect.DeepEqual(g, testCollectionGenders[cnt]) {
+			t.Errorf(`Should be equal to gender #%d`, cnt)
 		}
 
 		cnt++
@@ -334,8 +445,8 @@ func TestCollection_EachX(t *testing.T) {
 	cnt := 0
 
 	c.EachX(func(g *genderize.Gender) {
-		if !reflect.DeepEqual(g, testCollectionGenders[0]) && !reflect.DeepEqual(g, testCollectionGenders[1]) {
-			t.Error(`Should be one of two genders`)
+		if !reflect.DeepEqual(g, testCollectionGenders[cnt]) {
+			t.Errorf(`Should be equal to gender #%d`, cnt)
 		}
 
 		cnt++
@@ -349,8 +460,8 @@ func TestCollection_EachX(t *testing.T) {
 func TestCollection_EachX_Panic(t *testing.T) {
 	httpClient := testCollectionClient()
 
-	r := genderize.NewRequest(context.TODO()).
-		Name("Alice", "John")
+	// No names, so the response is empty.
+	r := genderize.NewRequest(context.TODO())
 
 	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
 		Execute(r)
@@ -383,3 +494,124 @@ func TestCollection_EachX_Panic(t *testing.T) {
 		t.Errorf(`Should be %d, %d given`, 2, cnt)
 	}
 }
+
+func TestCollection_Unknown(t *testing.T) {
+	httpClient := testCollectionClient(append(testCollectionGenders, &genderize.Gender{
+		Name: "Zyxw",
+	})...)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice", "John", "Zyxw")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	zyxw := c.FindX("Zyxw")
+	if zyxw.Gender != genderize.Unknown {
+		t.Errorf(`Should be "%s", "%s" given`, genderize.Unknown, zyxw.Gender)
+	}
+
+	if u := c.Unknown(); u.Length() != 1 {
+		t.Errorf(`Should be %d, %d given`, 1, u.Length())
+	}
+
+	names := c.UnknownNames()
+	if !reflect.DeepEqual(names, []string{"Zyxw"}) {
+		t.Errorf(`Should be %v, %v given`, []string{"Zyxw"}, names)
+	}
+}
+
+func TestCollection_MarshalJSON(t *testing.T) {
+	httpClient := testCollectionClient(append(testCollectionGenders, &genderize.Gender{
+		Name: "Zyxw",
+	})...)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice", "John", "Zyxw")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	var given genderize.Collection
+	if err = json.Unmarshal(b, &given); err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if !reflect.DeepEqual(&given, c) {
+		t.Error(`Should be equal`)
+	}
+
+	if given.LimitReset() != 789*time.Second {
+		t.Errorf(`Should be %d, %d given`, 789*time.Second, given.LimitReset())
+	}
+}
+
+func TestGender_MarshalJSON(t *testing.T) {
+	b, err := json.Marshal(&genderize.Gender{
+		Name: "Zyxw",
+	})
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	should := `{"name":"Zyxw","gender":null,"probability":0,"count":0}`
+	if string(b) != should {
+		t.Errorf(`Should be %s, %s given`, should, string(b))
+	}
+}
+
+func TestCollection_Find_CountryID(t *testing.T) {
+	genders := []*genderize.Gender{
+		{
+			Name:        "Andrea",
+			Gender:      "male",
+			Probability: 0.96,
+			Count:       1234,
+			CountryID:   "IT",
+		},
+		{
+			Name:        "Andrea",
+			Gender:      "female",
+			Probability: 0.8,
+			Count:       56789,
+		},
+	}
+
+	httpClient := testCollectionClient(genders...)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Andrea", "Andrea")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if g := c.FindX("Andrea", "IT"); !reflect.DeepEqual(g, genders[0]) {
+		t.Error(`Should be equal`)
+	}
+
+	if g := c.FindX("Andrea", ""); !reflect.DeepEqual(g, genders[1]) {
+		t.Error(`Should be equal`)
+	}
+
+	if _, err := c.Find("Andrea", "US"); !errors.Is(err, genderize.ErrNothingFound) {
+		t.Error(`Should be "genderize.ErrNothingFound"`)
+	}
+
+	if all := c.FindAll("Andrea"); len(all) != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, len(all))
+	}
+}
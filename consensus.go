@@ -0,0 +1,157 @@
+package genderize
+
+import "context"
+
+// Consensus of the gender lookups across countries.
+type Consensus struct {
+	// Names in request order.
+	Names []string `json:"names"`
+
+	// CountryIDs queried, empty ID of the global dataset goes first.
+	CountryIDs []string `json:"country_ids"`
+
+	// Matrix results per name and country: Matrix[i][j] is the result of
+	// Names[i] in CountryIDs[j].
+	Matrix [][]*Gender `json:"matrix"`
+
+	// Estimates combined results per name, weighted by count. Even split has
+	// unknown gender and EvenSplit set, see combine.
+	Estimates []*Gender `json:"estimates"`
+}
+
+// Find returns combined result by name.
+func (c *Consensus) Find(name string) (*Gender, error) {
+	for i, n := range c.Names {
+		if n == name {
+			return c.Estimates[i], nil
+		}
+	}
+
+	return nil, ErrNothingFound
+}
+
+// Breakdown returns per-country results by name in CountryIDs order.
+func (c *Consensus) Breakdown(name string) ([]*Gender, error) {
+	for i, n := range c.Names {
+		if n == name {
+			return c.Matrix[i], nil
+		}
+	}
+
+	return nil, ErrNothingFound
+}
+
+// Consensus queries names in the global dataset and in every country, then
+// combines the answers weighting each of them by its count.
+func (c *Client) Consensus(ctx context.Context, names []string, countryIDs ...string) (consensus *Consensus, err error) {
+	consensus = &Consensus{
+		Names:      names,
+		CountryIDs: []string{""},
+	}
+
+	seen := map[string]bool{"": true}
+
+	for _, countryID := range countryIDs {
+		if !seen[countryID] {
+			seen[countryID] = true
+			consensus.CountryIDs = append(consensus.CountryIDs, countryID)
+		}
+	}
+
+	if consensus.Matrix, err = c.lookupCountries(ctx, names, consensus.CountryIDs); err != nil {
+		return nil, err
+	}
+
+	consensus.Estimates = make([]*Gender, len(names))
+
+	for i, name := range names {
+		consensus.Estimates[i] = combine(name, consensus.Matrix[i], nil)
+	}
+
+	return
+}
+
+// lookupCountries queries names in every country and returns results per name
+// and country. Fallback policy is not applied, so every column holds data of
+// its own country.
+func (c *Client) lookupCountries(ctx context.Context, names, countryIDs []string) (matrix [][]*Gender, err error) {
+	matrix = make([][]*Gender, len(names))
+	for i := range matrix {
+		matrix[i] = make([]*Gender, len(countryIDs))
+	}
+
+	for j, countryID := range countryIDs {
+		for from := 0; from < len(names); from += MaxNames {
+			to := from + MaxNames
+			if to > len(names) {
+				to = len(names)
+			}
+
+			var collection *Collection
+			request := NewRequest(ctx).Name(names[from:to]...).CountryID(countryID)
+			if collection, err = c.executeSplit(request); err != nil {
+				return nil, err
+			}
+
+			for i := from; i < to; i++ {
+				if g, e := collection.At(i - from); e == nil {
+					matrix[i][j] = g
+				}
+			}
+		}
+	}
+
+	return
+}
+
+// combine returns result averaged over the results, each weighted by the
+// given weight or, when weights are nil, by its count. Unknown results are
+// left out. Estimate without any known data has unknown gender and zero
+// count, even split has unknown gender, probability 0.5 and EvenSplit set.
+func combine(name string, genders []*Gender, weights []float64) *Gender {
+	var male, total float64
+
+	estimate := &Gender{
+		Name: name,
+	}
+
+	for i, g := range genders {
+		if g == nil {
+			continue
+		}
+
+		p, ok := g.male()
+		if !ok {
+			continue
+		}
+
+		w := float64(g.Count)
+		if weights != nil {
+			w = weights[i]
+		}
+
+		male += w * p
+		total += w
+		estimate.Count += g.Count
+	}
+
+	if total == 0 {
+		return estimate
+	}
+
+	male /= total
+
+	switch {
+	case male > 0.5:
+		estimate.Gender = Male
+		estimate.Probability = male
+	case male < 0.5:
+		estimate.Gender = Female
+		estimate.Probability = 1 - male
+	default:
+		estimate.EvenSplit = true
+		estimate.Probability = 0.5
+	}
+
+	return estimate
+}
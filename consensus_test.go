@@ -0,0 +1,144 @@
+package genderize_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+// nolint:gochecknoglobals,golint,stylecheck
+var testConsensusGenders = map[string]map[string]*genderize.Gender{
+	"": {
+		"Andrea": {Name: "Andrea", Gender: genderize.Female, Probability: 0.6, Count: 1000},
+		"Mario":  {Name: "Mario", Gender: genderize.Male, Probability: 0.99, Count: 1000},
+		"Sasha":  {Name: "Sasha", Gender: genderize.Male, Probability: 0.5, Count: 200},
+	},
+	"IT": {
+		"Andrea": {Name: "Andrea", Gender: genderize.Male, Probability: 0.98, Count: 3000, CountryID: "IT"},
+		"Mario":  {Name: "Mario", Gender: genderize.Male, Probability: 0.99, Count: 5000, CountryID: "IT"},
+		"Sasha":  {Name: "Sasha", Gender: genderize.Male, Probability: 0.9, Count: 100, CountryID: "IT"},
+	},
+	"DE": {
+		"Andrea": {Name: "Andrea", Gender: genderize.Female, Probability: 0.9, Count: 1000, CountryID: "DE"},
+		"Mario":  {Name: "Mario", CountryID: "DE"},
+		"Sasha":  {Name: "Sasha", Gender: genderize.Female, Probability: 0.9, Count: 100, CountryID: "DE"},
+	},
+}
+
+func testConsensusClient() *http.Client {
+	return testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		query := req.URL.Query()
+		countryID := query.Get("country_id")
+
+		genders := make([]*genderize.Gender, 0)
+		for _, name := range query["name[]"] {
+			genders = append(genders, testConsensusGenders[countryID][name])
+		}
+
+		b, _ := json.Marshal(genders)
+
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "1000")
+		h.Set(genderize.HdrXRateLimitRemaining, "1000")
+		h.Set(genderize.HdrXRateReset, "0")
+
+		res = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}
+
+		return
+	})
+}
+
+func TestClient_Consensus(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testConsensusClient()))
+
+	c, err := client.Consensus(context.TODO(), []string{"Andrea", "Mario"}, "IT", "DE", "IT")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if len(c.CountryIDs) != 3 || c.CountryIDs[0] != "" {
+		t.Errorf(`Should be [ IT DE], %v given`, c.CountryIDs)
+	}
+
+	andrea, err := c.Find("Andrea")
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if andrea.Gender != genderize.Male || math.Abs(andrea.Probability-0.688) > 1e-9 || andrea.Count != 5000 {
+		t.Errorf(`Should be male/0.688/5000, %s/%f/%d given`, andrea.Gender, andrea.Probability, andrea.Count)
+	}
+
+	breakdown, err := c.Breakdown("Andrea")
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if breakdown[1].CountryID != "IT" || breakdown[2].CountryID != "DE" {
+		t.Errorf(`Should be ordered as country IDs`)
+	}
+
+	mario := c.Estimates[1]
+	if mario.Gender != genderize.Male || mario.Count != 6000 {
+		t.Errorf(`Should be male/6000, %s/%d given`, mario.Gender, mario.Count)
+	}
+
+	if _, err := c.Find("John"); !errors.Is(err, genderize.ErrNothingFound) {
+		t.Error(`Should be "genderize.ErrNothingFound"`)
+	}
+}
+
+func TestClient_Consensus_EvenSplit(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testConsensusClient()))
+
+	c, err := client.Consensus(context.TODO(), []string{"Sasha"}, "IT", "DE")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	sasha := c.Estimates[0]
+	if sasha.Gender.IsKnown() || !sasha.EvenSplit || sasha.Probability != 0.5 || sasha.Count != 400 {
+		t.Errorf(`Should be even split/0.5/400, %+v given`, sasha)
+	}
+}
+
+func TestClient_Consensus_Err(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testClientClient(func(_ *http.Request) (*http.Response, error) {
+		return nil, testClientErr
+	})))
+
+	if _, err := client.Consensus(context.TODO(), []string{"Andrea"}, "IT"); !errors.Is(err, testClientErr) {
+		t.Errorf(`Should be testClientErr, "%v" given`, err)
+	}
+}
+
+func TestClient_Consensus_Fallback(t *testing.T) {
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testConsensusClient()),
+		genderize.WithFallback(genderize.Policy{MinCount: 1000}),
+	)
+
+	c, err := client.Consensus(context.TODO(), []string{"Mario"}, "DE")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if de := c.Matrix[0][1]; de.Fallback || de.Gender.IsKnown() {
+		t.Errorf(`Should be country-specific result, %+v given`, de)
+	}
+
+	if mario := c.Estimates[0]; mario.Count != 1000 {
+		t.Errorf(`Should be %d, %d given`, 1000, mario.Count)
+	}
+}
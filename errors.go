@@ -1,7 +1,12 @@
 package genderize
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
 )
 
 var (
@@ -20,12 +25,133 @@ var (
 	// ErrValidation validation error.
 	ErrValidation = errors.New("validation error")
 
+	// ErrMissingName name parameter is missing, it is ErrValidation.
+	ErrMissingName = errors.New("missing name parameter")
+
+	// ErrInvalidName name parameter is invalid, it is ErrValidation.
+	ErrInvalidName = errors.New("invalid name parameter")
+
+	// ErrInvalidCountryID country_id parameter is invalid, it is ErrValidation.
+	ErrInvalidCountryID = errors.New("invalid country_id parameter")
+
 	// ErrTooManyRequests too many requests.
 	ErrTooManyRequests = errors.New("too many requests")
 
+	// ErrRequestLimitReached names limit of the time window is reached,
+	// it is ErrTooManyRequests.
+	ErrRequestLimitReached = errors.New("request limit reached")
+
+	// ErrRequestLimitTooLow names limit is too low to process request at all,
+	// it is ErrTooManyRequests.
+	ErrRequestLimitTooLow = errors.New("request limit too low")
+
 	// ErrInternal internal API server error.
 	ErrInternal = errors.New("internal API error")
 
+	// ErrGenderValue unexpected gender value.
+	ErrGenderValue = errors.New("invalid gender value")
+
 	// ErrNothingFound nothing found error.
 	ErrNothingFound = errors.New("nothing found")
+
+	// ErrCircuitOpen request is rejected by circuit breaker.
+	ErrCircuitOpen = errors.New("circuit breaker is open")
+
+	// ErrReserveTooHigh scheduler reserve leaves no room for a request in the
+	// time window.
+	ErrReserveTooHigh = errors.New("reserve too high for the request limit")
+
+	// ErrStop breaks collection iteration.
+	ErrStop = errors.New("stop iteration")
 )
+
+// APIError API error response. Use errors.Is to compare it with
+// ErrInvalidAPIKey, ErrValidation, ErrTooManyRequests etc.
+type APIError struct {
+	// StatusCode HTTP status code.
+	StatusCode int
+
+	// Message error message returned by API.
+	Message string
+
+	// Info rate limits info.
+	Info *Info
+
+	// RetryAfter duration to wait before the request can be retried.
+	RetryAfter time.Duration
+
+	err   error
+	cause error
+}
+
+// nolint:gochecknoglobals
+var apiErrorMessages = map[string]error{
+	"missing 'name' parameter":                 ErrMissingName,
+	"invalid 'name' parameter":                 ErrInvalidName,
+	"invalid 'country_id' parameter":           ErrInvalidCountryID,
+	"request limit reached":                    ErrRequestLimitReached,
+	"request limit too low to process request": ErrRequestLimitTooLow,
+}
+
+func newAPIError(statusCode int, message string, info *Info, err error) *APIError {
+	return &APIError{
+		StatusCode: statusCode,
+		Message:    message,
+		Info:       info,
+		err:        err,
+		cause:      apiErrorMessages[strings.ToLower(strings.TrimSpace(message))],
+	}
+}
+
+// Error returns error message.
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return e.err.Error()
+	}
+
+	return fmt.Sprintf(`%s cause %s`, e.err, e.Message)
+}
+
+// Unwrap returns the most precise sentinel error known for the API message.
+func (e *APIError) Unwrap() error {
+	if e.cause != nil {
+		return e.cause
+	}
+
+	return e.err
+}
+
+// Is reports whether the error belongs to the target error class.
+func (e *APIError) Is(target error) bool {
+	return e.err == target
+}
+
+// IsRetryable reports whether the request can be retried later: rate limit is
+// reached, API failed internally or request timed out.
+func IsRetryable(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, ErrRequestLimitTooLow),
+		errors.Is(err, context.Canceled):
+		return false
+	case errors.Is(err, ErrTooManyRequests),
+		errors.Is(err, ErrInternal),
+		errors.Is(err, context.DeadlineExceeded):
+		return true
+	}
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsQuotaError reports whether the error is caused by rate limits or
+// subscription.
+func IsQuotaError(err error) bool {
+	return errors.Is(err, ErrTooManyRequests) || errors.Is(err, ErrSubscriptionIsNotActive)
+}
+
+// IsAuthError reports whether the error is caused by API key.
+func IsAuthError(err error) bool {
+	return errors.Is(err, ErrInvalidAPIKey)
+}
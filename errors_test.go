@@ -0,0 +1,138 @@
+package genderize_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+type errorsTableRow struct {
+	status  int
+	message string
+	class   error
+	cause   error
+}
+
+func testErrorsExecute(status int, message string) error {
+	httpClient := testClientClient(func(_ *http.Request) (res *http.Response, err error) {
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "1000")
+		h.Set(genderize.HdrXRateLimitRemaining, "0")
+		h.Set(genderize.HdrXRateReset, "60")
+
+		res = &http.Response{
+			StatusCode: status,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(fmt.Sprintf(`{"error":"%s"}`, message))),
+		}
+
+		return
+	})
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	_, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+
+	return err
+}
+
+func TestAPIError_Is(t *testing.T) {
+	table := []errorsTableRow{
+		{
+			status:  http.StatusUnprocessableEntity,
+			message: "Missing 'name' parameter",
+			class:   genderize.ErrValidation,
+			cause:   genderize.ErrMissingName,
+		},
+		{
+			status:  http.StatusUnprocessableEntity,
+			message: "Invalid 'name' parameter",
+			class:   genderize.ErrValidation,
+			cause:   genderize.ErrInvalidName,
+		},
+		{
+			status:  http.StatusUnprocessableEntity,
+			message: "Invalid 'country_id' parameter",
+			class:   genderize.ErrValidation,
+			cause:   genderize.ErrInvalidCountryID,
+		},
+		{
+			status:  http.StatusTooManyRequests,
+			message: "Request limit reached",
+			class:   genderize.ErrTooManyRequests,
+			cause:   genderize.ErrRequestLimitReached,
+		},
+		{
+			status:  http.StatusTooManyRequests,
+			message: "Request limit too low to process request",
+			class:   genderize.ErrTooManyRequests,
+			cause:   genderize.ErrRequestLimitTooLow,
+		},
+	}
+
+	for _, r := range table {
+		err := testErrorsExecute(r.status, r.message)
+
+		if !errors.Is(err, r.class) {
+			t.Errorf(`Should be "%s", "%v" given`, r.class, err)
+		}
+
+		if !errors.Is(err, r.cause) {
+			t.Errorf(`Should be "%s", "%v" given`, r.cause, err)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	if !genderize.IsRetryable(testErrorsExecute(http.StatusTooManyRequests, "Request limit reached")) {
+		t.Error(`Should be retryable`)
+	}
+
+	if !genderize.IsRetryable(testErrorsExecute(http.StatusInternalServerError, "")) {
+		t.Error(`Should be retryable`)
+	}
+
+	if genderize.IsRetryable(testErrorsExecute(http.StatusTooManyRequests, "Request limit too low to process request")) {
+		t.Error(`Should not be retryable`)
+	}
+
+	if genderize.IsRetryable(testErrorsExecute(http.StatusUnprocessableEntity, "Missing 'name' parameter")) {
+		t.Error(`Should not be retryable`)
+	}
+
+	if genderize.IsRetryable(nil) {
+		t.Error(`Should not be retryable`)
+	}
+}
+
+func TestIsQuotaError(t *testing.T) {
+	if !genderize.IsQuotaError(testErrorsExecute(http.StatusTooManyRequests, "Request limit reached")) {
+		t.Error(`Should be quota error`)
+	}
+
+	if !genderize.IsQuotaError(testErrorsExecute(http.StatusPaymentRequired, "Subscription is not active")) {
+		t.Error(`Should be quota error`)
+	}
+
+	if genderize.IsQuotaError(testErrorsExecute(http.StatusUnauthorized, "Invalid API key")) {
+		t.Error(`Should not be quota error`)
+	}
+}
+
+func TestIsAuthError(t *testing.T) {
+	if !genderize.IsAuthError(testErrorsExecute(http.StatusUnauthorized, "Invalid API key")) {
+		t.Error(`Should be auth error`)
+	}
+
+	if genderize.IsAuthError(testErrorsExecute(http.StatusInternalServerError, "")) {
+		t.Error(`Should not be auth error`)
+	}
+}
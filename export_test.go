@@ -0,0 +1,15 @@
+package genderize
+
+import "time"
+
+// SetResetUnit sets unit of the rate limits reset headers, so tests need not
+// wait for whole seconds.
+func SetResetUnit(c *Client, d time.Duration) {
+	c.resetUnit = d
+}
+
+// SetSchedulerMinWait sets minimal wait of the scheduler before retrying
+// rejected request.
+func SetSchedulerMinWait(s *Scheduler, d time.Duration) {
+	s.minWait = d
+}
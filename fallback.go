@@ -0,0 +1,55 @@
+package genderize
+
+import "context"
+
+// fallback replaces thin country-specific results with global ones when the
+// latter are better. Fallback is best-effort: failed global lookup is logged
+// and country-specific results are kept.
+func (c *Client) fallback(ctx context.Context, collection *Collection) {
+	policy := c.options.Fallback
+
+	var (
+		names     []string
+		positions []int
+	)
+
+	for i, g := range collection.genders {
+		if g.CountryID != "" && policy.Classify(g) != ClassConfident {
+			names = append(names, g.Name)
+			positions = append(positions, i)
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	matrix, err := c.lookupCountries(ctx, names, []string{""})
+	if err != nil {
+		c.logf("fallback: %s", err)
+
+		return
+	}
+
+	for i, row := range matrix {
+		local, global := collection.genders[positions[i]], row[0]
+		if global == nil || !fallbackIsBetter(policy, local, global) {
+			continue
+		}
+
+		g := *global
+		g.CountryID = local.CountryID
+		g.Fallback = true
+
+		collection.genders[positions[i]] = &g
+	}
+}
+
+// fallbackIsBetter reports whether global result should replace the local one.
+func fallbackIsBetter(policy *Policy, local, global *Gender) bool {
+	if policy.Classify(global) == ClassConfident {
+		return true
+	}
+
+	return !local.Gender.IsKnown() && global.Gender.IsKnown()
+}
@@ -0,0 +1,60 @@
+package genderize_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+func TestClient_Execute_Fallback(t *testing.T) {
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testConsensusClient()),
+		genderize.WithFallback(genderize.Policy{
+			MinCount:       2000,
+			MinProbability: 0.8,
+		}),
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		NameIn("Andrea", "DE").
+		NameIn("Mario", "DE").
+		NameIn("Andrea", "IT")
+
+	c, err := client.Execute(r)
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	andreaDE := c.AtX(0)
+	if andreaDE.Fallback || andreaDE.Gender != genderize.Female || andreaDE.Probability != 0.9 {
+		t.Error(`Should keep country-specific result`)
+	}
+
+	mario := c.FindX("Mario", "DE")
+	if !mario.Fallback || mario.Gender != genderize.Male || mario.Count != 1000 {
+		t.Errorf(`Should be global fallback, %+v given`, mario)
+	}
+
+	andreaIT := c.AtX(2)
+	if andreaIT.Fallback || andreaIT.CountryID != "IT" {
+		t.Error(`Should keep confident result`)
+	}
+}
+
+func TestClient_Execute_Fallback_Confident(t *testing.T) {
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testConsensusClient()),
+		genderize.WithFallback(genderize.Policy{
+			MinCount: 1000,
+		}),
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		NameIn("Mario", "DE")
+
+	mario := client.ExecuteX(r).FirstX()
+	if !mario.Fallback || mario.CountryID != "DE" {
+		t.Errorf(`Should be global fallback, %+v given`, mario)
+	}
+}
@@ -0,0 +1,73 @@
+package genderize
+
+import "context"
+
+// Future result of the asynchronous request execution.
+type Future struct {
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	collection *Collection
+	err        error
+}
+
+// Done returns channel closed when the request is complete.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel cancels the request context.
+func (f *Future) Cancel() {
+	f.cancel()
+}
+
+// Wait waits until the request is complete or context is done and returns
+// execution result. Complete request returns its result even when context is
+// done.
+func (f *Future) Wait(ctx context.Context) (*Collection, error) {
+	select {
+	case <-f.done:
+		return f.collection, f.err
+	default:
+	}
+
+	select {
+	case <-f.done:
+		return f.collection, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ExecuteAsync executes API request in background.
+func (c *Client) ExecuteAsync(request *Request) *Future {
+	ctx, cancel := context.WithCancel(request.ctx)
+
+	f := &Future{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	go func() {
+		defer close(f.done)
+		defer cancel()
+
+		f.collection, f.err = c.Execute(request.withContext(ctx))
+	}()
+
+	return f
+}
+
+// WaitAll waits for every future and returns collections and errors in the
+// order of futures. When context is done, errors of incomplete futures are
+// set to the context error.
+func WaitAll(ctx context.Context, futures ...*Future) (collections []*Collection, errs []error) {
+	collections = make([]*Collection, len(futures))
+	errs = make([]error, len(futures))
+
+	for i, f := range futures {
+		collections[i], errs[i] = f.Wait(ctx)
+	}
+
+	return
+}
@@ -0,0 +1,112 @@
+package genderize_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alexeyco/genderize"
+)
+
+func TestClient_ExecuteAsync(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testCollectionClient(testCollectionGenders...)))
+
+	f := client.ExecuteAsync(genderize.NewRequest(context.TODO()).
+		Name("Alice", "John"))
+
+	<-f.Done()
+
+	c, err := f.Wait(context.TODO())
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if c.Length() != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, c.Length())
+	}
+}
+
+func TestFuture_Cancel(t *testing.T) {
+	httpClient := testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		<-req.Context().Done()
+
+		return nil, req.Context().Err()
+	})
+
+	client := genderize.NewClient(genderize.WithHTTPClient(httpClient))
+
+	f := client.ExecuteAsync(genderize.NewRequest(context.TODO()).
+		Name("Alice"))
+
+	f.Cancel()
+
+	_, err := f.Wait(context.TODO())
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf(`Should be context.Canceled, "%v" given`, err)
+	}
+}
+
+func TestFuture_Wait_Timeout(t *testing.T) {
+	httpClient := testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		<-req.Context().Done()
+
+		return nil, req.Context().Err()
+	})
+
+	client := genderize.NewClient(genderize.WithHTTPClient(httpClient))
+
+	f := client.ExecuteAsync(genderize.NewRequest(context.TODO()).
+		Name("Alice"))
+	defer f.Cancel()
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := f.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf(`Should be context.DeadlineExceeded, "%v" given`, err)
+	}
+}
+
+func TestWaitAll(t *testing.T) {
+	ok := genderize.NewClient(genderize.WithHTTPClient(testCollectionClient(testCollectionGenders...)))
+	failing := genderize.NewClient(genderize.WithHTTPClient(testClientClient(func(_ *http.Request) (*http.Response, error) {
+		return nil, testClientErr
+	})))
+
+	collections, errs := genderize.WaitAll(context.TODO(),
+		ok.ExecuteAsync(genderize.NewRequest(context.TODO()).Name("Alice", "John")),
+		failing.ExecuteAsync(genderize.NewRequest(context.TODO()).Name("Alice")),
+	)
+
+	if errs[0] != nil || collections[0].Length() != 2 {
+		t.Errorf(`Should be successful, "%v" given`, errs[0])
+	}
+
+	if !errors.Is(errs[1], testClientErr) {
+		t.Errorf(`Should be testClientErr, "%v" given`, errs[1])
+	}
+}
+
+func TestWaitAll_Canceled(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testCollectionClient(testCollectionGenders...)))
+
+	futures := make([]*genderize.Future, 50)
+	for i := range futures {
+		futures[i] = client.ExecuteAsync(genderize.NewRequest(context.TODO()).Name("Alice", "John"))
+		<-futures[i].Done()
+	}
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	collections, errs := genderize.WaitAll(ctx, futures...)
+
+	for i := range futures {
+		if errs[i] != nil || collections[i].Length() != 2 {
+			t.Errorf(`Should be successful, "%v" given`, errs[i])
+		}
+	}
+}
@@ -9,4 +9,13 @@ const (
 
 	// HdrXRateReset seconds remaining until a new time window opens.
 	HdrXRateReset = "X-Rate-Reset"
+
+	// HdrXRateLimitReset alternate spelling of HdrXRateReset.
+	HdrXRateLimitReset = "X-Rate-Limit-Reset"
+
+	// HdrXRateLimitResetCompact alternate spelling of HdrXRateReset.
+	HdrXRateLimitResetCompact = "X-RateLimit-Reset"
+
+	// HdrRetryAfter standard seconds or date to wait before retrying.
+	HdrRetryAfter = "Retry-After"
 )
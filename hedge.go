@@ -0,0 +1,160 @@
+package genderize
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	hedgeWindow     = 100
+	hedgeMinSamples = 20
+	hedgePercentile = 0.95
+)
+
+type hedger struct {
+	delay   time.Duration
+	ratio   float64
+	reserve int64
+
+	mu        sync.Mutex
+	requests  int64
+	hedged    int64
+	latencies []time.Duration
+	next      int
+}
+
+// wait returns delay before the duplicate request: configured one or observed
+// p95 latency. False is returned while there are not enough observations.
+func (h *hedger) wait() (time.Duration, bool) {
+	if h.delay > 0 {
+		return h.delay, true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < hedgeMinSamples {
+		return 0, false
+	}
+
+	latencies := make([]time.Duration, len(h.latencies))
+	copy(latencies, h.latencies)
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	return latencies[int(float64(len(latencies)-1)*hedgePercentile)], true
+}
+
+func (h *hedger) observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < hedgeWindow {
+		h.latencies = append(h.latencies, d)
+
+		return
+	}
+
+	h.latencies[h.next] = d
+	h.next = (h.next + 1) % hedgeWindow
+}
+
+// start counts request.
+func (h *hedger) start() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.requests++
+}
+
+// allow reports whether the duplicate request fits hedging ratio and quota
+// reserve, and counts it.
+func (h *hedger) allow(info *Info, names int) bool {
+	if info != nil && !info.Unknown && info.Remaining-int64(names) < h.reserve {
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.ratio > 0 && float64(h.hedged+1) > h.ratio*float64(h.requests) {
+		return false
+	}
+
+	h.hedged++
+
+	return true
+}
+
+type hedgeResult struct {
+	collection *Collection
+	err        error
+}
+
+// hedge executes request and, if it is slow, its duplicate. The first
+// successful answer wins, the other request is cancelled.
+func (c *Client) hedge(ctx context.Context, u string, names int) (collection *Collection, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	attempt := func() {
+		collection, err := c.do(ctx, u)
+		results <- hedgeResult{collection: collection, err: err}
+	}
+
+	c.hedger.start()
+	started := time.Now()
+
+	go attempt()
+
+	delay, ok := c.hedger.wait()
+	if !ok {
+		r := <-results
+		c.observeLatency(started, r.err)
+
+		return r.collection, r.err
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case r := <-results:
+		c.observeLatency(started, r.err)
+
+		return r.collection, r.err
+	case <-timer.C:
+	}
+
+	pending := 1
+
+	if c.hedger.allow(c.Info(), names) {
+		c.logf("GET %s: hedged after %s", u, delay)
+
+		pending++
+
+		go attempt()
+	}
+
+	for {
+		r := <-results
+		pending--
+
+		if r.err == nil || pending == 0 {
+			c.observeLatency(started, r.err)
+
+			return r.collection, r.err
+		}
+	}
+}
+
+func (c *Client) observeLatency(started time.Time, err error) {
+	if err == nil {
+		c.hedger.observe(time.Since(started))
+	}
+}
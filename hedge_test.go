@@ -0,0 +1,142 @@
+package genderize_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexeyco/genderize"
+)
+
+const (
+	hedgeDelay = 20 * time.Millisecond
+	hedgeSlow  = 2 * time.Second
+)
+
+func testHedgeClient(calls, cancelled *int32, remaining string) *http.Client {
+	return testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		if atomic.AddInt32(calls, 1) == 1 {
+			select {
+			case <-req.Context().Done():
+				atomic.AddInt32(cancelled, 1)
+
+				return nil, req.Context().Err()
+			case <-time.After(hedgeSlow):
+			}
+		}
+
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "1000")
+		h.Set(genderize.HdrXRateLimitRemaining, remaining)
+		h.Set(genderize.HdrXRateReset, "0")
+
+		res = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(`[{"name":"Alice","gender":"female","probability":0.9,"count":100}]`)),
+		}
+
+		return
+	})
+}
+
+func TestClient_Execute_Hedging(t *testing.T) {
+	var calls, cancelled int32
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testHedgeClient(&calls, &cancelled, "999")),
+		genderize.WithHedging(hedgeDelay, 0),
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	started := time.Now()
+
+	c, err := client.Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if time.Since(started) >= hedgeSlow {
+		t.Error(`Should not wait for the slow request`)
+	}
+
+	if c.FirstX().Name != "Alice" {
+		t.Errorf(`Should be "%s", "%s" given`, "Alice", c.FirstX().Name)
+	}
+
+	if atomic.LoadInt32(&calls) != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, calls)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&cancelled) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if atomic.LoadInt32(&cancelled) != 1 {
+		t.Error(`Slow request should be cancelled`)
+	}
+}
+
+func TestClient_Execute_Hedging_Reserve(t *testing.T) {
+	var calls, cancelled int32
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testHedgeClient(&calls, &cancelled, "5")),
+		genderize.WithHedging(hedgeDelay, 0),
+		genderize.WithHedgeReserve(10),
+	)
+
+	// The first request is hedged: nothing is known about quota yet.
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	if _, err := client.Execute(r); err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if info := client.Info(); info == nil || info.Remaining != 5 {
+		t.Errorf(`Should be %d, %v given`, 5, info)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*hedgeDelay)
+	defer cancel()
+
+	r = genderize.NewRequest(ctx).
+		Name("Alice")
+
+	_, _ = client.Execute(r)
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf(`Should be %d, %d given`, 1, calls)
+	}
+}
+
+func TestClient_Execute_Hedging_Ratio(t *testing.T) {
+	var calls, cancelled int32
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testHedgeClient(&calls, &cancelled, "999")),
+		genderize.WithHedging(hedgeDelay, 0.5),
+	)
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*hedgeDelay)
+	defer cancel()
+
+	r := genderize.NewRequest(ctx).
+		Name("Alice")
+
+	_, _ = client.Execute(r)
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf(`Should be %d, %d given`, 1, calls)
+	}
+}
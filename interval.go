@@ -0,0 +1,199 @@
+package genderize
+
+import "math"
+
+const (
+	// DefaultLevel default confidence level of intervals.
+	DefaultLevel = 0.95
+
+	betaEpsilon    = 1e-14
+	betaTiny       = 1e-300
+	betaIterations = 10000
+	bisections     = 100
+)
+
+// IntervalMethod method of the probability interval estimation.
+type IntervalMethod int
+
+const (
+	// IntervalWilson Wilson score interval.
+	IntervalWilson IntervalMethod = iota
+
+	// IntervalCredible Bayesian credible interval with Jeffreys prior.
+	IntervalCredible
+)
+
+// Interval of the gender probability.
+type Interval struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// Width of the interval.
+func (i Interval) Width() float64 {
+	return i.Upper - i.Lower
+}
+
+// Interval returns probability interval estimated with the method at the given
+// confidence level, e.g. 0.95. Zero level stands for DefaultLevel.
+func (g *Gender) Interval(method IntervalMethod, level float64) Interval {
+	if method == IntervalCredible {
+		return g.CredibleInterval(level)
+	}
+
+	return g.WilsonInterval(level)
+}
+
+// WilsonInterval returns Wilson score interval of the probability at the given
+// confidence level. Zero level stands for DefaultLevel.
+func (g *Gender) WilsonInterval(level float64) Interval {
+	lower, upper := wilson(g.Probability, g.Count, zScore(level))
+
+	return Interval{
+		Lower: lower,
+		Upper: upper,
+	}
+}
+
+// CredibleInterval returns equal-tailed Bayesian credible interval of the
+// probability with Jeffreys prior at the given confidence level. Zero level
+// stands for DefaultLevel.
+func (g *Gender) CredibleInterval(level float64) Interval {
+	if g.Count <= 0 {
+		return Interval{Lower: 0, Upper: 1}
+	}
+
+	if level <= 0 || level >= 1 {
+		level = DefaultLevel
+	}
+
+	n := float64(g.Count)
+	a := g.Probability*n + 0.5
+	b := (1-g.Probability)*n + 0.5
+
+	i := Interval{
+		Lower: betaQuantile(a, b, (1-level)/2),
+		Upper: betaQuantile(a, b, (1+level)/2),
+	}
+
+	if g.Probability <= 0 {
+		i.Lower = 0
+	}
+
+	if g.Probability >= 1 {
+		i.Upper = 1
+	}
+
+	return i
+}
+
+// Score returns ranking score of the result: the lower bound of the 95% Wilson
+// score interval. Unlike probability it penalizes results based on few rows.
+func (g *Gender) Score() float64 {
+	if !g.Gender.IsKnown() {
+		return 0
+	}
+
+	return g.WilsonInterval(DefaultLevel).Lower
+}
+
+func zScore(level float64) float64 {
+	if level <= 0 || level >= 1 {
+		level = DefaultLevel
+	}
+
+	return math.Sqrt2 * math.Erfinv(level)
+}
+
+// wilson returns Wilson score interval of the probability p observed in n rows.
+func wilson(p float64, n int64, z float64) (lower, upper float64) {
+	if n <= 0 {
+		return 0, 1
+	}
+
+	fn := float64(n)
+	z2 := z * z
+
+	denominator := 1 + z2/fn
+	center := (p + z2/(2*fn)) / denominator
+	margin := z * math.Sqrt(p*(1-p)/fn+z2/(4*fn*fn)) / denominator
+
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// betaQuantile returns x such that regularized incomplete beta function
+// I(x; a, b) equals q.
+func betaQuantile(a, b, q float64) float64 {
+	lower, upper := 0.0, 1.0
+
+	for i := 0; i < bisections; i++ {
+		x := (lower + upper) / 2
+		if betaInc(a, b, x) < q {
+			lower = x
+		} else {
+			upper = x
+		}
+	}
+
+	return (lower + upper) / 2
+}
+
+// betaInc returns regularized incomplete beta function I(x; a, b).
+func betaInc(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+
+	if x >= 1 {
+		return 1
+	}
+
+	lab, _ := math.Lgamma(a + b)
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+
+	front := math.Exp(a*math.Log(x) + b*math.Log(1-x) + lab - la - lb)
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(a, b, x) / a
+	}
+
+	return 1 - front*betaFraction(b, a, 1-x)/b
+}
+
+// betaFraction evaluates continued fraction of the incomplete beta function
+// with modified Lentz's method.
+func betaFraction(a, b, x float64) float64 {
+	tiny := func(v float64) float64 {
+		if math.Abs(v) < betaTiny {
+			return betaTiny
+		}
+
+		return v
+	}
+
+	c := 1.0
+	d := 1 / tiny(1-(a+b)*x/(a+1))
+	h := d
+
+	for i := 1; i <= betaIterations; i++ {
+		m := float64(i)
+
+		aa := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 / tiny(1+aa*d)
+		c = tiny(1 + aa/c)
+		h *= d * c
+
+		aa = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 / tiny(1+aa*d)
+		c = tiny(1 + aa/c)
+		delta := d * c
+		h *= delta
+
+		if math.Abs(delta-1) < betaEpsilon {
+			break
+		}
+	}
+
+	return h
+}
@@ -0,0 +1,98 @@
+package genderize_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+const intervalDelta = 1e-6
+
+type intervalTableRow struct {
+	gender *genderize.Gender
+	should genderize.Interval
+}
+
+func intervalEqual(a, b genderize.Interval) bool {
+	return math.Abs(a.Lower-b.Lower) < intervalDelta && math.Abs(a.Upper-b.Upper) < intervalDelta
+}
+
+func TestGender_WilsonInterval(t *testing.T) {
+	table := []intervalTableRow{
+		{
+			gender: &genderize.Gender{Probability: 0.9, Count: 100},
+			should: genderize.Interval{Lower: 0.825634, Upper: 0.944771},
+		},
+		{
+			gender: &genderize.Gender{Probability: 0.99, Count: 3},
+			should: genderize.Interval{Lower: 0.429810, Upper: 0.999923},
+		},
+		{
+			gender: &genderize.Gender{},
+			should: genderize.Interval{Lower: 0, Upper: 1},
+		},
+	}
+
+	for _, r := range table {
+		if given := r.gender.WilsonInterval(0.95); !intervalEqual(given, r.should) {
+			t.Errorf(`Should be %v, %v given`, r.should, given)
+		}
+	}
+}
+
+func TestGender_CredibleInterval(t *testing.T) {
+	table := []intervalTableRow{
+		{
+			gender: &genderize.Gender{Probability: 0.9, Count: 100},
+			should: genderize.Interval{Lower: 0.829876, Upper: 0.947415},
+		},
+		{
+			gender: &genderize.Gender{Probability: 0.99, Count: 3},
+			should: genderize.Interval{Lower: 0.451339, Upper: 0.999766},
+		},
+		{
+			gender: &genderize.Gender{Probability: 1, Count: 5},
+			should: genderize.Interval{Lower: 0.620623, Upper: 1},
+		},
+		{
+			gender: &genderize.Gender{},
+			should: genderize.Interval{Lower: 0, Upper: 1},
+		},
+	}
+
+	for _, r := range table {
+		if given := r.gender.CredibleInterval(0.95); !intervalEqual(given, r.should) {
+			t.Errorf(`Should be %v, %v given`, r.should, given)
+		}
+	}
+}
+
+func TestGender_Interval(t *testing.T) {
+	g := &genderize.Gender{Probability: 0.9, Count: 100}
+
+	if g.Interval(genderize.IntervalWilson, 0) != g.WilsonInterval(genderize.DefaultLevel) {
+		t.Error(`Should be equal`)
+	}
+
+	if g.Interval(genderize.IntervalCredible, 0) != g.CredibleInterval(genderize.DefaultLevel) {
+		t.Error(`Should be equal`)
+	}
+
+	if g.WilsonInterval(0.99).Width() <= g.WilsonInterval(0.9).Width() {
+		t.Error(`Should be wider`)
+	}
+}
+
+func TestGender_Score(t *testing.T) {
+	few := &genderize.Gender{Gender: genderize.Female, Probability: 0.99, Count: 3}
+	many := &genderize.Gender{Gender: genderize.Female, Probability: 0.9, Count: 80000}
+
+	if few.Score() >= many.Score() {
+		t.Errorf(`%f should be less than %f`, few.Score(), many.Score())
+	}
+
+	if s := (&genderize.Gender{Probability: 0.9, Count: 100}).Score(); s != 0 {
+		t.Errorf(`Should be %f, %f given`, 0.0, s)
+	}
+}
@@ -0,0 +1,108 @@
+package genderize
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// CollectionWalkCallback iteration callback. Return ErrStop to break iteration.
+type CollectionWalkCallback func(i int, g *Gender) error
+
+// CollectionParallelCallback parallel iteration callback. Context is cancelled
+// as soon as any callback fails. Return ErrStop to break iteration.
+type CollectionParallelCallback func(ctx context.Context, i int, g *Gender) error
+
+// CollectionSeq iterator over collection results, can be used with
+// range-over-func: for i, g := range collection.All() {}.
+type CollectionSeq func(yield func(i int, g *Gender) bool)
+
+// Walk iterates over collection in request order until callback returns error.
+// ErrStop breaks iteration and is not returned. Empty collection is not an error.
+func (c *Collection) Walk(fn CollectionWalkCallback) error {
+	for i, g := range c.genders {
+		if err := fn(i, g); err != nil {
+			if errors.Is(err, ErrStop) {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// All returns iterator over collection results in request order.
+func (c *Collection) All() CollectionSeq {
+	return func(yield func(i int, g *Gender) bool) {
+		for i, g := range c.genders {
+			if !yield(i, g) {
+				return
+			}
+		}
+	}
+}
+
+// WalkParallel calls callback for every result in at most workers goroutines.
+// The first callback error cancels the rest and is returned, ErrStop breaks
+// iteration and is not returned.
+func (c *Collection) WalkParallel(ctx context.Context, workers int, fn CollectionParallelCallback) (err error) {
+	if workers < 1 {
+		workers = 1
+	}
+
+	walkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+	)
+
+	jobs := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range jobs {
+				if walkCtx.Err() != nil {
+					continue
+				}
+
+				if e := fn(walkCtx, i, c.genders[i]); e != nil {
+					once.Do(func() {
+						err = e
+					})
+
+					cancel()
+				}
+			}
+		}()
+	}
+
+loop:
+	for i := range c.genders {
+		select {
+		case jobs <- i:
+		case <-walkCtx.Done():
+			break loop
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	if errors.Is(err, ErrStop) {
+		return nil
+	}
+
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	return
+}
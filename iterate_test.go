@@ -0,0 +1,142 @@
+package genderize_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+func TestCollection_Walk(t *testing.T) {
+	c := testQueryCollection(t)
+
+	var names []string
+
+	err := c.Walk(func(i int, g *genderize.Gender) error {
+		names = append(names, g.Name)
+
+		if i == 1 {
+			return genderize.ErrStop
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if len(names) != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, len(names))
+	}
+}
+
+func TestCollection_Walk_Err(t *testing.T) {
+	c := testQueryCollection(t)
+
+	cnt := 0
+
+	err := c.Walk(func(i int, g *genderize.Gender) error {
+		cnt++
+
+		return testClientErr
+	})
+	if !errors.Is(err, testClientErr) {
+		t.Errorf(`Should be "%s", "%v" given`, testClientErr, err)
+	}
+
+	if cnt != 1 {
+		t.Errorf(`Should be %d, %d given`, 1, cnt)
+	}
+}
+
+func TestCollection_Walk_Empty(t *testing.T) {
+	c := testQueryCollection(t).Top(0)
+
+	err := c.Walk(func(i int, g *genderize.Gender) error {
+		t.Error(`Should not be called`)
+
+		return nil
+	})
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+}
+
+func TestCollection_All(t *testing.T) {
+	c := testQueryCollection(t)
+
+	var names []string
+
+	c.All()(func(i int, g *genderize.Gender) bool {
+		names = append(names, g.Name)
+
+		return i < 2
+	})
+
+	if len(names) != 3 {
+		t.Errorf(`Should be %d, %d given`, 3, len(names))
+	}
+}
+
+func TestCollection_WalkParallel(t *testing.T) {
+	c := testQueryCollection(t)
+
+	var cnt int64
+
+	err := c.WalkParallel(context.TODO(), 2, func(_ context.Context, _ int, _ *genderize.Gender) error {
+		atomic.AddInt64(&cnt, 1)
+
+		return nil
+	})
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if cnt != int64(c.Length()) {
+		t.Errorf(`Should be %d, %d given`, c.Length(), cnt)
+	}
+}
+
+func TestCollection_WalkParallel_Err(t *testing.T) {
+	c := testQueryCollection(t)
+
+	err := c.WalkParallel(context.TODO(), 1, func(ctx context.Context, i int, _ *genderize.Gender) error {
+		if i == 0 {
+			return testClientErr
+		}
+
+		t.Error(`Should not be called`)
+
+		return nil
+	})
+	if !errors.Is(err, testClientErr) {
+		t.Errorf(`Should be "%s", "%v" given`, testClientErr, err)
+	}
+}
+
+func TestCollection_WalkParallel_Stop(t *testing.T) {
+	c := testQueryCollection(t)
+
+	err := c.WalkParallel(context.TODO(), 4, func(_ context.Context, _ int, _ *genderize.Gender) error {
+		return genderize.ErrStop
+	})
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+}
+
+func TestCollection_WalkParallel_Cancel(t *testing.T) {
+	c := testQueryCollection(t)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+
+	err := c.WalkParallel(ctx, 1, func(_ context.Context, _ int, _ *genderize.Gender) error {
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf(`Should be "%s", "%v" given`, context.Canceled, err)
+	}
+}
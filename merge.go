@@ -0,0 +1,152 @@
+package genderize
+
+// MergePolicy resolves conflicts between results with the same name and
+// country ID.
+type MergePolicy int
+
+const (
+	// MergeNewest result of the newer collection wins.
+	MergeNewest MergePolicy = iota
+
+	// MergeHighestCount result based on more data rows wins, newer one on tie.
+	MergeHighestCount
+)
+
+type genderKey struct {
+	name      string
+	countryID string
+}
+
+func (g *Gender) key() genderKey {
+	return genderKey{
+		name:      g.Name,
+		countryID: g.CountryID,
+	}
+}
+
+func (c *Collection) index() map[genderKey]int {
+	index := map[genderKey]int{}
+
+	for i, g := range c.genders {
+		if _, ok := index[g.key()]; !ok {
+			index[g.key()] = i
+		}
+	}
+
+	return index
+}
+
+// Merge returns new collection with results of both collections, one per name
+// and country ID. The receiver is considered older, conflicts are resolved with
+// the policy. Rate limits info of the newer collection is kept.
+func (c *Collection) Merge(newer *Collection, policy MergePolicy) *Collection {
+	merged := &Collection{
+		info: newer.info,
+	}
+
+	if merged.info == nil {
+		merged.info = c.info
+	}
+
+	index := map[genderKey]int{}
+
+	for _, genders := range [][]*Gender{c.genders, newer.genders} {
+		for _, g := range genders {
+			i, ok := index[g.key()]
+			if !ok {
+				index[g.key()] = len(merged.genders)
+				merged.genders = append(merged.genders, g)
+
+				continue
+			}
+
+			if policy == MergeNewest || g.Count >= merged.genders[i].Count {
+				merged.genders[i] = g
+			}
+		}
+	}
+
+	return merged
+}
+
+// Change of the result between two collections. Deltas are new minus old.
+type Change struct {
+	Old *Gender `json:"old"`
+	New *Gender `json:"new"`
+
+	// MaleProbabilityDelta change of the probability the name is male, so it
+	// stays meaningful when the gender label flips. Unknown gender counts as
+	// probability 0.5.
+	MaleProbabilityDelta float64 `json:"male_probability_delta"`
+
+	// CountDelta change of the number of data rows.
+	CountDelta int64 `json:"count_delta"`
+}
+
+// GenderChanged reports whether the gender label has changed.
+func (c *Change) GenderChanged() bool {
+	return c.Old.Gender != c.New.Gender
+}
+
+// Diff of two collections.
+type Diff struct {
+	Added   []*Gender `json:"added"`
+	Removed []*Gender `json:"removed"`
+	Changed []*Change `json:"changed"`
+}
+
+// Diff compares the collection with the newer one. Results are matched by name
+// and country ID.
+func (c *Collection) Diff(newer *Collection) *Diff {
+	diff := &Diff{}
+
+	oldIndex := c.index()
+	newIndex := newer.index()
+
+	for i, g := range c.genders {
+		if oldIndex[g.key()] != i {
+			continue
+		}
+
+		if _, ok := newIndex[g.key()]; !ok {
+			diff.Removed = append(diff.Removed, g)
+		}
+	}
+
+	for i, g := range newer.genders {
+		if newIndex[g.key()] != i {
+			continue
+		}
+
+		j, ok := oldIndex[g.key()]
+		if !ok {
+			diff.Added = append(diff.Added, g)
+
+			continue
+		}
+
+		old := c.genders[j]
+		if old.Gender == g.Gender && old.Probability == g.Probability && old.Count == g.Count {
+			continue
+		}
+
+		diff.Changed = append(diff.Changed, &Change{
+			Old:                  old,
+			New:                  g,
+			MaleProbabilityDelta: maleProbability(g) - maleProbability(old),
+			CountDelta:           g.Count - old.Count,
+		})
+	}
+
+	return diff
+}
+
+// maleProbability returns probability the name is male, 0.5 when gender is
+// unknown.
+func maleProbability(g *Gender) float64 {
+	if p, ok := g.male(); ok {
+		return p
+	}
+
+	return 0.5
+}
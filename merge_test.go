@@ -0,0 +1,130 @@
+package genderize_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+func testMergeCollection(t *testing.T, genders ...*genderize.Gender) *genderize.Collection {
+	t.Helper()
+
+	r := genderize.NewRequest(context.TODO())
+
+	for _, g := range genders {
+		r.Name(g.Name)
+	}
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(testCollectionClient(genders...))).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	return c
+}
+
+func TestCollection_Merge(t *testing.T) {
+	older := testMergeCollection(t,
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.9, Count: 500},
+		&genderize.Gender{Name: "John", Gender: genderize.Male, Probability: 0.9, Count: 100},
+	)
+
+	newer := testMergeCollection(t,
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.95, Count: 400},
+		&genderize.Gender{Name: "John", Gender: genderize.Male, Probability: 0.95, Count: 200},
+		&genderize.Gender{Name: "Mario", Gender: genderize.Male, Probability: 0.99, Count: 300},
+	)
+
+	merged := older.Merge(newer, genderize.MergeNewest)
+
+	if given := merged.Names(); !reflect.DeepEqual(given, []string{"Alice", "John", "Mario"}) {
+		t.Errorf(`Should be %v, %v given`, []string{"Alice", "John", "Mario"}, given)
+	}
+
+	if alice := merged.FindX("Alice"); alice.Count != 400 {
+		t.Errorf(`Should be %d, %d given`, 400, alice.Count)
+	}
+
+	merged = older.Merge(newer, genderize.MergeHighestCount)
+
+	if alice := merged.FindX("Alice"); alice.Count != 500 {
+		t.Errorf(`Should be %d, %d given`, 500, alice.Count)
+	}
+
+	if john := merged.FindX("John"); john.Count != 200 {
+		t.Errorf(`Should be %d, %d given`, 200, john.Count)
+	}
+}
+
+func TestCollection_Diff(t *testing.T) {
+	older := testMergeCollection(t,
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.9, Count: 500},
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Female, Probability: 0.6, Count: 100},
+		&genderize.Gender{Name: "John", Gender: genderize.Male, Probability: 0.9, Count: 100},
+	)
+
+	newer := testMergeCollection(t,
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.9, Count: 500},
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.55, Count: 150},
+		&genderize.Gender{Name: "Mario", Gender: genderize.Male, Probability: 0.99, Count: 300},
+	)
+
+	diff := older.Diff(newer)
+
+	if len(diff.Added) != 1 || diff.Added[0].Name != "Mario" {
+		t.Errorf(`Should be [Mario], %v given`, diff.Added)
+	}
+
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "John" {
+		t.Errorf(`Should be [John], %v given`, diff.Removed)
+	}
+
+	if len(diff.Changed) != 1 {
+		t.Fatalf(`Should be %d, %d given`, 1, len(diff.Changed))
+	}
+
+	change := diff.Changed[0]
+
+	if !change.GenderChanged() {
+		t.Error(`Should be changed`)
+	}
+
+	// Female 0.6 is male 0.4, so male 0.55 is 0.15 more.
+	if math.Abs(change.MaleProbabilityDelta-0.15) > 1e-9 {
+		t.Errorf(`Should be %f, %f given`, 0.15, change.MaleProbabilityDelta)
+	}
+
+	if change.CountDelta != 50 {
+		t.Errorf(`Should be %d, %d given`, 50, change.CountDelta)
+	}
+}
+
+func TestCollection_Diff_LabelFlip(t *testing.T) {
+	older := testMergeCollection(t,
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Male, Probability: 0.9, Count: 100},
+		&genderize.Gender{Name: "Zyxw"},
+	)
+
+	newer := testMergeCollection(t,
+		&genderize.Gender{Name: "Sasha", Gender: genderize.Female, Probability: 0.9, Count: 100},
+		&genderize.Gender{Name: "Zyxw", Gender: genderize.Male, Probability: 0.7, Count: 10},
+	)
+
+	diff := older.Diff(newer)
+
+	if len(diff.Changed) != 2 {
+		t.Fatalf(`Should be %d, %d given`, 2, len(diff.Changed))
+	}
+
+	if sasha := diff.Changed[0]; !sasha.GenderChanged() || math.Abs(sasha.MaleProbabilityDelta+0.8) > 1e-9 {
+		t.Errorf(`Should be flipped by %f, %f given`, -0.8, sasha.MaleProbabilityDelta)
+	}
+
+	if zyxw := diff.Changed[1]; math.Abs(zyxw.MaleProbabilityDelta-0.2) > 1e-9 {
+		t.Errorf(`Should be %f, %f given`, 0.2, zyxw.MaleProbabilityDelta)
+	}
+}
@@ -1,11 +1,63 @@
 package genderize
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
+
+// Logger of client requests and failures, *log.Logger fits it. Messages never
+// contain API key.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
 
 // Options of a client.
 type Options struct {
 	APIKey     string
 	HTTPClient *http.Client
+
+	// LenientHeaders do not fail when rate limit headers are missing or
+	// malformed, mark rate limits info unknown instead.
+	LenientHeaders bool
+
+	// Logger of requests and failures.
+	Logger Logger
+
+	// BreakerThreshold number of consecutive upstream failures opening
+	// circuit breaker, zero disables it.
+	BreakerThreshold int
+
+	// BreakerCoolDown duration circuit breaker stays open before a trial
+	// request.
+	BreakerCoolDown time.Duration
+
+	// BreakerListener circuit breaker state change callback.
+	BreakerListener BreakerListener
+
+	// Hedge enables hedged requests.
+	Hedge bool
+
+	// HedgeDelay delay before the duplicate request, observed p95 latency
+	// when zero.
+	HedgeDelay time.Duration
+
+	// HedgeRatio maximal share of hedged requests, unlimited when zero.
+	HedgeRatio float64
+
+	// HedgeReserve number of names in the current time window that hedged
+	// requests must not touch.
+	HedgeReserve int64
+
+	// StreamLinger duration Stream waits for more names before sending
+	// incomplete batch.
+	StreamLinger time.Duration
+
+	// RequestInterval minimal interval between Stream requests.
+	RequestInterval time.Duration
+
+	// Fallback policy country-specific results must satisfy, otherwise names
+	// are queried in the global dataset.
+	Fallback *Policy
 }
 
 // Option callback.
@@ -24,3 +76,77 @@ func WithHTTPClient(httpClient *http.Client) Option {
 		o.HTTPClient = httpClient
 	}
 }
+
+// WithLenientHeaders makes client tolerant to missing or malformed rate limit
+// headers.
+func WithLenientHeaders() Option {
+	return func(o *Options) {
+		o.LenientHeaders = true
+	}
+}
+
+// WithLogger sets requests logger.
+func WithLogger(logger Logger) Option {
+	return func(o *Options) {
+		o.Logger = logger
+	}
+}
+
+// WithCircuitBreaker enables circuit breaker opening after threshold
+// consecutive upstream failures for coolDown duration.
+func WithCircuitBreaker(threshold int, coolDown time.Duration) Option {
+	return func(o *Options) {
+		o.BreakerThreshold = threshold
+		o.BreakerCoolDown = coolDown
+	}
+}
+
+// WithBreakerListener sets circuit breaker state change callback.
+func WithBreakerListener(listener BreakerListener) Option {
+	return func(o *Options) {
+		o.BreakerListener = listener
+	}
+}
+
+// WithHedging enables hedged requests: when API does not answer within delay,
+// duplicate request is sent and the first answer wins. Zero delay stands for
+// observed p95 latency. Ratio limits share of hedged requests, zero is
+// unlimited. Every hedged request is billed.
+func WithHedging(delay time.Duration, ratio float64) Option {
+	return func(o *Options) {
+		o.Hedge = true
+		o.HedgeDelay = delay
+		o.HedgeRatio = ratio
+	}
+}
+
+// WithHedgeReserve disables hedging when fewer than reserve names would stay
+// in the current time window.
+func WithHedgeReserve(reserve int64) Option {
+	return func(o *Options) {
+		o.HedgeReserve = reserve
+	}
+}
+
+// WithStreamLinger sets duration Stream waits for more names before sending
+// incomplete batch.
+func WithStreamLinger(linger time.Duration) Option {
+	return func(o *Options) {
+		o.StreamLinger = linger
+	}
+}
+
+// WithRequestInterval sets minimal interval between Stream requests.
+func WithRequestInterval(interval time.Duration) Option {
+	return func(o *Options) {
+		o.RequestInterval = interval
+	}
+}
+
+// WithFallback enables global fallback: country-specific results that are not
+// confident according to the policy are replaced with better global ones.
+func WithFallback(policy Policy) Option {
+	return func(o *Options) {
+		o.Fallback = &policy
+	}
+}
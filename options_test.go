@@ -1,8 +1,11 @@
 package genderize_test
 
 import (
+	"io/ioutil"
+	"log"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/alexeyco/genderize"
 )
@@ -26,3 +29,93 @@ func TestWithHTTPClient(t *testing.T) {
 		t.Error(`Should not be nil`)
 	}
 }
+
+func TestWithLenientHeaders(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithLenientHeaders()(o)
+
+	if !o.LenientHeaders {
+		t.Error(`Should be true`)
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithLogger(log.New(ioutil.Discard, "", 0))(o)
+
+	if o.Logger == nil {
+		t.Error(`Should not be nil`)
+	}
+}
+
+func TestWithCircuitBreaker(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithCircuitBreaker(5, time.Minute)(o)
+
+	if o.BreakerThreshold != 5 || o.BreakerCoolDown != time.Minute {
+		t.Errorf(`Should be %d/%s, %d/%s given`, 5, time.Minute, o.BreakerThreshold, o.BreakerCoolDown)
+	}
+}
+
+func TestWithBreakerListener(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithBreakerListener(func(_, _ genderize.BreakerState) {})(o)
+
+	if o.BreakerListener == nil {
+		t.Error(`Should not be nil`)
+	}
+}
+
+func TestWithHedging(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithHedging(time.Second, 0.1)(o)
+
+	if !o.Hedge || o.HedgeDelay != time.Second || o.HedgeRatio != 0.1 {
+		t.Error(`Should be enabled`)
+	}
+}
+
+func TestWithHedgeReserve(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithHedgeReserve(100)(o)
+
+	if o.HedgeReserve != 100 {
+		t.Errorf(`Should be %d, %d given`, 100, o.HedgeReserve)
+	}
+}
+
+func TestWithStreamLinger(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithStreamLinger(time.Second)(o)
+
+	if o.StreamLinger != time.Second {
+		t.Errorf(`Should be %s, %s given`, time.Second, o.StreamLinger)
+	}
+}
+
+func TestWithRequestInterval(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithRequestInterval(time.Second)(o)
+
+	if o.RequestInterval != time.Second {
+		t.Errorf(`Should be %s, %s given`, time.Second, o.RequestInterval)
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	o := &genderize.Options{}
+
+	genderize.WithFallback(genderize.Policy{MinCount: 10})(o)
+
+	if o.Fallback == nil || o.Fallback.MinCount != 10 {
+		t.Error(`Should be set`)
+	}
+}
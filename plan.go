@@ -0,0 +1,84 @@
+package genderize
+
+import "context"
+
+// Plan of the lookup job computed without API calls, see Client.DryRun.
+type Plan struct {
+	// Names number of names given.
+	Names int `json:"names"`
+
+	// Duplicates number of repeated occurrences of names, e.g. 2 for
+	// ["A", "A", "A"].
+	Duplicates int `json:"duplicates"`
+
+	// Billed number of unique names the job is billed for.
+	Billed int64 `json:"billed"`
+
+	// Requests API requests the job would send, up to MaxNames names each.
+	Requests []*Request `json:"-"`
+
+	// Windows projected number of time windows the job takes, including
+	// the current one, zero when rate limits are unknown.
+	Windows int `json:"windows"`
+
+	// FitsCurrentWindow reports whether the job fits names remaining in the
+	// current time window.
+	FitsCurrentWindow bool `json:"fits_current_window"`
+
+	// Info rate limits info the projection is based on, as of the planning
+	// time.
+	Info *Info `json:"info"`
+}
+
+// DryRun plans lookup of the names without any API calls: names are deduped
+// and split into requests, quota windows are projected from the last known
+// rate limits info, the time window is considered open again once its reset
+// has passed.
+func (c *Client) DryRun(ctx context.Context, names []string, countryID ...string) *Plan {
+	plan := &Plan{
+		Names: len(names),
+		Info:  c.currentInfo(),
+	}
+
+	seen := map[string]bool{}
+
+	var unique []string
+
+	for _, name := range names {
+		if seen[name] {
+			plan.Duplicates++
+
+			continue
+		}
+
+		seen[name] = true
+		unique = append(unique, name)
+	}
+
+	plan.Billed = int64(len(unique))
+
+	for from := 0; from < len(unique); from += MaxNames {
+		to := from + MaxNames
+		if to > len(unique) {
+			to = len(unique)
+		}
+
+		r := NewRequest(ctx).Name(unique[from:to]...)
+		if len(countryID) != 0 {
+			r.CountryID(countryID[0])
+		}
+
+		plan.Requests = append(plan.Requests, r)
+	}
+
+	if info := plan.Info; info != nil && !info.Unknown && info.Limit > 0 {
+		plan.FitsCurrentWindow = plan.Billed <= info.Remaining
+		plan.Windows = 1
+
+		if rest := plan.Billed - info.Remaining; rest > 0 {
+			plan.Windows += int((rest + info.Limit - 1) / info.Limit)
+		}
+	}
+
+	return plan
+}
@@ -0,0 +1,105 @@
+package genderize_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+func testPlanNames(n int) []string {
+	names := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		names = append(names, "Name"+strconv.Itoa(i))
+	}
+
+	return names
+}
+
+func TestClient_DryRun(t *testing.T) {
+	var calls int32
+
+	client := genderize.NewClient(genderize.WithHTTPClient(testStreamClient(&calls, 1000)))
+
+	names := append(testPlanNames(25), "Name0", "Name1")
+
+	plan := client.DryRun(context.TODO(), names, "US")
+
+	if plan.Names != 27 || plan.Duplicates != 2 || plan.Billed != 25 {
+		t.Errorf(`Should be 27/2/25, %d/%d/%d given`, plan.Names, plan.Duplicates, plan.Billed)
+	}
+
+	if len(plan.Requests) != 3 {
+		t.Errorf(`Should be %d, %d given`, 3, len(plan.Requests))
+	}
+
+	u, _ := url.Parse(plan.Requests[2].Encode())
+	if q := u.Query(); len(q["name[]"]) != 5 || q.Get("country_id") != "US" {
+		t.Errorf(`Should be 5 names in US, "%s" given`, u)
+	}
+
+	if plan.Windows != 0 || plan.FitsCurrentWindow {
+		t.Error(`Should be unknown`)
+	}
+
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Errorf(`Should be %d, %d given`, 0, calls)
+	}
+}
+
+func TestClient_DryRun_Windows(t *testing.T) {
+	var calls int32
+
+	// Limit is 1000 names per window, 10 names are remaining.
+	client := genderize.NewClient(genderize.WithHTTPClient(testStreamClient(&calls, 10)))
+	client.ExecuteX(genderize.NewRequest(context.TODO()).Name("Alice"))
+
+	plan := client.DryRun(context.TODO(), testPlanNames(2500))
+
+	if plan.Windows != 4 || plan.FitsCurrentWindow {
+		t.Errorf(`Should be 4 windows, %d given`, plan.Windows)
+	}
+
+	plan = client.DryRun(context.TODO(), testPlanNames(10))
+
+	if plan.Windows != 1 || !plan.FitsCurrentWindow {
+		t.Errorf(`Should fit current window, %d windows given`, plan.Windows)
+	}
+}
+
+func TestClient_DryRun_WindowReset(t *testing.T) {
+	// Nothing remains in the window, but it resets right away.
+	client := genderize.NewClient(genderize.WithHTTPClient(testClientClient(func(_ *http.Request) (*http.Response, error) {
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "20")
+		h.Set(genderize.HdrXRateLimitRemaining, "0")
+		h.Set(genderize.HdrXRateReset, "0")
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(`[{"name":"Alice"}]`)),
+		}, nil
+	})))
+
+	client.ExecuteX(genderize.NewRequest(context.TODO()).Name("Alice"))
+
+	plan := client.DryRun(context.TODO(), testPlanNames(30))
+
+	if plan.Windows != 2 || plan.FitsCurrentWindow || plan.Info.Remaining != 20 {
+		t.Errorf(`Should be 2 windows, %d given`, plan.Windows)
+	}
+
+	plan = client.DryRun(context.TODO(), testPlanNames(20))
+
+	if plan.Windows != 1 || !plan.FitsCurrentWindow {
+		t.Errorf(`Should fit current window, %d windows given`, plan.Windows)
+	}
+}
@@ -0,0 +1,145 @@
+package genderize
+
+import "sort"
+
+// SortKey collection sort key.
+type SortKey int
+
+const (
+	// SortByName sorts by name in ascending order.
+	SortByName SortKey = iota
+
+	// SortByProbability sorts by probability in descending order.
+	SortByProbability
+
+	// SortByCount sorts by count in descending order.
+	SortByCount
+)
+
+// GroupKey collection group key.
+type GroupKey int
+
+const (
+	// GroupByGender groups by gender: "male", "female" or "unknown".
+	GroupByGender GroupKey = iota
+
+	// GroupByCountry groups by country ID, empty ID stands for global results.
+	GroupByCountry
+)
+
+// Predicate collection filter callback.
+type Predicate func(g *Gender) bool
+
+func (c *Collection) with(genders []*Gender) *Collection {
+	return &Collection{
+		info:    c.info,
+		genders: genders,
+	}
+}
+
+// Filter returns new collection of results matching the predicate.
+func (c *Collection) Filter(fn Predicate) *Collection {
+	var genders []*Gender
+
+	for _, g := range c.genders {
+		if fn(g) {
+			genders = append(genders, g)
+		}
+	}
+
+	return c.with(genders)
+}
+
+// SortBy returns new collection sorted by the key. Sort is stable, so equal
+// results keep the request order.
+func (c *Collection) SortBy(key SortKey) *Collection {
+	genders := c.Slice()
+
+	var less func(a, b *Gender) bool
+
+	switch key {
+	case SortByProbability:
+		less = func(a, b *Gender) bool {
+			return a.Probability > b.Probability
+		}
+	case SortByCount:
+		less = func(a, b *Gender) bool {
+			return a.Count > b.Count
+		}
+	default:
+		less = func(a, b *Gender) bool {
+			return a.Name < b.Name
+		}
+	}
+
+	sort.SliceStable(genders, func(i, j int) bool {
+		return less(genders[i], genders[j])
+	})
+
+	return c.with(genders)
+}
+
+// Reverse returns new collection in reverse order.
+func (c *Collection) Reverse() *Collection {
+	genders := make([]*Gender, len(c.genders))
+
+	for i, g := range c.genders {
+		genders[len(genders)-1-i] = g
+	}
+
+	return c.with(genders)
+}
+
+// GroupBy returns new collections grouped by the key.
+func (c *Collection) GroupBy(key GroupKey) map[string]*Collection {
+	groups := map[string]*Collection{}
+
+	for _, g := range c.genders {
+		k := g.CountryID
+		if key == GroupByGender {
+			k = g.Gender.String()
+		}
+
+		group, ok := groups[k]
+		if !ok {
+			group = c.with(nil)
+			groups[k] = group
+		}
+
+		group.genders = append(group.genders, g)
+	}
+
+	return groups
+}
+
+// Top returns new collection of the first n results.
+func (c *Collection) Top(n int) *Collection {
+	if n > len(c.genders) {
+		n = len(c.genders)
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	return c.with(c.Slice()[:n])
+}
+
+// Names returns names of the collection results.
+func (c *Collection) Names() []string {
+	names := make([]string, len(c.genders))
+
+	for i, g := range c.genders {
+		names[i] = g.Name
+	}
+
+	return names
+}
+
+// Slice returns a copy of the collection results.
+func (c *Collection) Slice() []*Gender {
+	genders := make([]*Gender, len(c.genders))
+	copy(genders, c.genders)
+
+	return genders
+}
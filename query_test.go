@@ -0,0 +1,125 @@
+package genderize_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+func testQueryCollection(t *testing.T) *genderize.Collection {
+	t.Helper()
+
+	httpClient := testCollectionClient(
+		&genderize.Gender{Name: "John", Gender: genderize.Male, Probability: 0.9, Count: 300, CountryID: "US"},
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.95, Count: 100, CountryID: "US"},
+		&genderize.Gender{Name: "Zyxw", CountryID: "IT"},
+		&genderize.Gender{Name: "Mario", Gender: genderize.Male, Probability: 0.99, Count: 200, CountryID: "IT"},
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("John", "Alice", "Zyxw", "Mario")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	return c
+}
+
+func TestCollection_Filter(t *testing.T) {
+	c := testQueryCollection(t).Filter(func(g *genderize.Gender) bool {
+		return g.Gender == genderize.Male
+	})
+
+	should := []string{"John", "Mario"}
+	if !reflect.DeepEqual(c.Names(), should) {
+		t.Errorf(`Should be %v, %v given`, should, c.Names())
+	}
+
+	if c.Limit() != 123 {
+		t.Errorf(`Should be %d, %d given`, 123, c.Limit())
+	}
+}
+
+func TestCollection_SortBy(t *testing.T) {
+	c := testQueryCollection(t)
+
+	table := map[genderize.SortKey][]string{
+		genderize.SortByName:        {"Alice", "John", "Mario", "Zyxw"},
+		genderize.SortByProbability: {"Mario", "Alice", "John", "Zyxw"},
+		genderize.SortByCount:       {"John", "Mario", "Alice", "Zyxw"},
+	}
+
+	for key, should := range table {
+		if given := c.SortBy(key).Names(); !reflect.DeepEqual(given, should) {
+			t.Errorf(`Should be %v, %v given`, should, given)
+		}
+	}
+
+	should := []string{"John", "Alice", "Zyxw", "Mario"}
+	if !reflect.DeepEqual(c.Names(), should) {
+		t.Errorf(`Should be %v, %v given`, should, c.Names())
+	}
+}
+
+func TestCollection_Reverse(t *testing.T) {
+	should := []string{"Mario", "Zyxw", "Alice", "John"}
+
+	if given := testQueryCollection(t).Reverse().Names(); !reflect.DeepEqual(given, should) {
+		t.Errorf(`Should be %v, %v given`, should, given)
+	}
+}
+
+func TestCollection_GroupBy(t *testing.T) {
+	c := testQueryCollection(t)
+
+	byGender := c.GroupBy(genderize.GroupByGender)
+	if len(byGender) != 3 {
+		t.Errorf(`Should be %d, %d given`, 3, len(byGender))
+	}
+
+	if given := byGender["male"].Names(); !reflect.DeepEqual(given, []string{"John", "Mario"}) {
+		t.Errorf(`Should be %v, %v given`, []string{"John", "Mario"}, given)
+	}
+
+	if given := byGender["unknown"].Names(); !reflect.DeepEqual(given, []string{"Zyxw"}) {
+		t.Errorf(`Should be %v, %v given`, []string{"Zyxw"}, given)
+	}
+
+	byCountry := c.GroupBy(genderize.GroupByCountry)
+	if given := byCountry["IT"].Names(); !reflect.DeepEqual(given, []string{"Zyxw", "Mario"}) {
+		t.Errorf(`Should be %v, %v given`, []string{"Zyxw", "Mario"}, given)
+	}
+}
+
+func TestCollection_Top(t *testing.T) {
+	c := testQueryCollection(t)
+
+	should := []string{"Mario", "Alice"}
+	if given := c.SortBy(genderize.SortByProbability).Top(2).Names(); !reflect.DeepEqual(given, should) {
+		t.Errorf(`Should be %v, %v given`, should, given)
+	}
+
+	if c.Top(10).Length() != 4 {
+		t.Errorf(`Should be %d, %d given`, 4, c.Top(10).Length())
+	}
+
+	if c.Top(-1).Length() != 0 {
+		t.Errorf(`Should be %d, %d given`, 0, c.Top(-1).Length())
+	}
+}
+
+func TestCollection_Slice(t *testing.T) {
+	c := testQueryCollection(t)
+
+	s := c.Slice()
+	s[0] = nil
+
+	if c.FirstX() == nil {
+		t.Error(`Should not be nil`)
+	}
+}
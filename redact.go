@@ -0,0 +1,66 @@
+package genderize
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redacted replaces API key in redacted strings.
+const Redacted = "REDACTED"
+
+// nolint:gochecknoglobals
+var apiKeyPattern = regexp.MustCompile(`(?i)(apikey=)[^&\s"']*`)
+
+// Redact replaces values of the apikey query parameter in the string, e.g.
+// URL or error message, and every occurrence of the given API keys.
+func Redact(s string, apiKey ...string) string {
+	s = apiKeyPattern.ReplaceAllString(s, "${1}"+Redacted)
+
+	for _, k := range apiKey {
+		if k != "" {
+			s = strings.ReplaceAll(s, k, Redacted)
+			s = strings.ReplaceAll(s, url.QueryEscape(k), Redacted)
+		}
+	}
+
+	return s
+}
+
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// RedactError returns error with API key scrubbed from its message. URL of
+// *url.Error is redacted as well, so it is safe to log.
+func RedactError(err error, apiKey ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	if urlErr, ok := err.(*url.Error); ok {
+		return &url.Error{
+			Op:  urlErr.Op,
+			URL: Redact(urlErr.URL, apiKey...),
+			Err: RedactError(urlErr.Err, apiKey...),
+		}
+	}
+
+	if message := Redact(err.Error(), apiKey...); message != err.Error() {
+		return &redactedError{
+			err:     err,
+			message: message,
+		}
+	}
+
+	return err
+}
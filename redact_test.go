@@ -0,0 +1,103 @@
+package genderize_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+const redactAPIKey = "MyAwesomeAPIKey"
+
+type redactLogger struct {
+	lines []string
+}
+
+func (l *redactLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestRedact(t *testing.T) {
+	given := genderize.Redact("https://api.genderize.io?name%5B%5D=Alice&apikey=foo&country_id=US")
+	should := "https://api.genderize.io?name%5B%5D=Alice&apikey=REDACTED&country_id=US"
+
+	if given != should {
+		t.Errorf(`Should be "%s", "%s" given`, should, given)
+	}
+
+	given = genderize.Redact("invalid key bar", "bar")
+	should = "invalid key REDACTED"
+
+	if given != should {
+		t.Errorf(`Should be "%s", "%s" given`, should, given)
+	}
+}
+
+func TestRedactError(t *testing.T) {
+	err := &url.Error{
+		Op:  "Get",
+		URL: "https://api.genderize.io?apikey=" + redactAPIKey,
+		Err: testClientErr,
+	}
+
+	redacted := genderize.RedactError(err, redactAPIKey)
+
+	if strings.Contains(redacted.Error(), redactAPIKey) {
+		t.Errorf(`Should not contain API key, "%s" given`, redacted)
+	}
+
+	if !errors.Is(redacted, testClientErr) {
+		t.Error(`Should be testClientErr`)
+	}
+
+	if genderize.RedactError(nil) != nil {
+		t.Error(`Should be nil`)
+	}
+}
+
+func TestClient_Execute_Redact(t *testing.T) {
+	httpClient := testClientClient(func(_ *http.Request) (res *http.Response, err error) {
+		return nil, testClientErr
+	})
+
+	logger := &redactLogger{}
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice")
+
+	_, err := genderize.NewClient(
+		genderize.WithHTTPClient(httpClient),
+		genderize.WithAPIKey(redactAPIKey),
+		genderize.WithLogger(logger),
+	).Execute(r)
+	if err == nil {
+		t.Fatal(`Should not be nil`)
+	}
+
+	if strings.Contains(err.Error(), redactAPIKey) {
+		t.Errorf(`Should not contain API key, "%s" given`, err)
+	}
+
+	if !errors.Is(err, testClientErr) {
+		t.Error(`Should be testClientErr`)
+	}
+
+	if len(logger.lines) != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, len(logger.lines))
+	}
+
+	for _, line := range logger.lines {
+		if strings.Contains(line, redactAPIKey) {
+			t.Errorf(`Should not contain API key, "%s" given`, line)
+		}
+	}
+
+	if strings.Contains(r.String(), redactAPIKey) {
+		t.Errorf(`Should not contain API key, "%s" given`, r.String())
+	}
+}
@@ -8,13 +8,22 @@ import (
 
 const endpoint = "https://api.genderize.io"
 
+// MaxNames maximal number of names in a single API request.
+const MaxNames = 10
+
+type requestName struct {
+	name      string
+	countryID string
+}
+
 // Request API request.
 type Request struct {
 	ctx context.Context
 
-	mu    sync.Mutex
-	url   *url.URL
-	query url.Values
+	mu        sync.Mutex
+	url       *url.URL
+	names     []requestName
+	countryID string
 }
 
 // Name sets person names.
@@ -23,41 +32,119 @@ func (r *Request) Name(name ...string) *Request {
 	defer r.mu.Unlock()
 
 	for _, n := range name {
-		r.query.Add("name[]", n)
+		r.names = append(r.names, requestName{name: n})
 	}
 
 	return r
 }
 
-// CountryID sets country ISO 3166-1 alpha-2 ID.
+// NameIn sets person name with its own country ISO 3166-1 alpha-2 ID.
+// Client.Execute sends names of different countries in separate API calls.
+func (r *Request) NameIn(name, countryID string) *Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.names = append(r.names, requestName{
+		name:      name,
+		countryID: countryID,
+	})
+
+	return r
+}
+
+// CountryID sets country ISO 3166-1 alpha-2 ID for names without their own.
 func (r *Request) CountryID(countryID string) *Request {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.query.Set("country_id", countryID)
+	r.countryID = countryID
 
 	return r
 }
 
-// Encode returns request URL.
+// Encode returns request URL. Per-name country IDs are ignored, use
+// Client.Execute to query them.
 func (r *Request) Encode(apiKey ...string) string {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	query := url.Values{}
+
+	for _, n := range r.names {
+		query.Add("name[]", n.name)
+	}
+
+	if r.countryID != "" {
+		query.Set("country_id", r.countryID)
+	}
+
 	if len(apiKey) != 0 && apiKey[0] != "" {
-		r.query.Set("apikey", apiKey[0])
+		query.Set("apikey", apiKey[0])
 	}
 
-	r.url.RawQuery = r.query.Encode()
+	u := *r.url
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// String returns request URL without API key, safe for debugging.
+func (r *Request) String() string {
+	return r.Encode()
+}
+
+func (r *Request) withContext(ctx context.Context) *Request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request := NewRequest(ctx)
+	request.names = append(request.names, r.names...)
+	request.countryID = r.countryID
+
+	return request
+}
+
+func (r *Request) length() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.names)
+}
+
+// split groups names by country. Positions of names in the original request
+// are returned for each group.
+func (r *Request) split() (requests []*Request, positions [][]int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	groups := map[string]int{}
+
+	for i, n := range r.names {
+		countryID := n.countryID
+		if countryID == "" {
+			countryID = r.countryID
+		}
+
+		g, ok := groups[countryID]
+		if !ok {
+			g = len(requests)
+			groups[countryID] = g
+
+			requests = append(requests, NewRequest(r.ctx).CountryID(countryID))
+			positions = append(positions, nil)
+		}
+
+		requests[g].names = append(requests[g].names, requestName{name: n.name})
+		positions[g] = append(positions[g], i)
+	}
 
-	return r.url.String()
+	return
 }
 
 // NewRequest returns new request instance.
 func NewRequest(ctx context.Context) *Request {
 	r := &Request{
-		ctx:   ctx,
-		query: url.Values{},
+		ctx: ctx,
 	}
 
 	r.url, _ = url.Parse(endpoint)
@@ -34,6 +34,10 @@ func TestNewRequest_Encode(t *testing.T) {
 			should: "https://api.genderize.io?name%5B%5D=Alice&name%5B%5D=John&country_id=US&apikey=MyAwesomeAPIKey",
 			given:  genderize.NewRequest(ctx).Name("Alice").Name("John").CountryID("US").Encode("MyAwesomeAPIKey"),
 		},
+		{
+			should: "https://api.genderize.io?name%5B%5D=Alice&name%5B%5D=Andrea&country_id=US",
+			given:  genderize.NewRequest(ctx).Name("Alice").NameIn("Andrea", "IT").CountryID("US").Encode(),
+		},
 	}
 
 	for _, r := range table {
@@ -0,0 +1,269 @@
+package genderize
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// schedulerMinWait minimal wait before retrying rejected request when API
+// does not tell when the time window opens.
+const schedulerMinWait = time.Second
+
+// Progress of the scheduled job.
+type Progress struct {
+	// Done number of names looked up.
+	Done int64 `json:"done"`
+
+	// Total number of unique names to look up.
+	Total int64 `json:"total"`
+
+	// RequestsDone number of requests executed.
+	RequestsDone int `json:"requests_done"`
+
+	// Requests number of requests to execute.
+	Requests int `json:"requests"`
+
+	// Paused is set when the job is paused.
+	Paused bool `json:"paused"`
+
+	// WaitingUntil time the job sleeps until the time window opens, zero
+	// when it does not sleep.
+	WaitingUntil time.Time `json:"waiting_until"`
+
+	// ETA estimated from the throughput so far, zero until the first request
+	// is complete.
+	ETA time.Duration `json:"eta"`
+}
+
+// Scheduler runs lookup jobs larger than the time window: names are consumed
+// until the remaining rate limit reaches the reserve, then the job sleeps until
+// the time window opens and continues.
+type Scheduler struct {
+	client  *Client
+	plan    *Plan
+	reserve int64
+	minWait time.Duration
+
+	run sync.Mutex
+
+	mu           sync.Mutex
+	next         int
+	done         int64
+	elapsed      time.Duration
+	started      time.Time
+	resumed      chan struct{}
+	waitingUntil time.Time
+	collection   *Collection
+}
+
+// Schedule returns scheduler of the names lookup. Duplicate names are looked
+// up once, reserve names of every time window are left untouched. Negative
+// reserve is treated as zero.
+func (c *Client) Schedule(names []string, reserve int64, countryID ...string) *Scheduler {
+	if reserve < 0 {
+		reserve = 0
+	}
+
+	return &Scheduler{
+		client:     c,
+		plan:       c.DryRun(context.Background(), names, countryID...),
+		reserve:    reserve,
+		minWait:    schedulerMinWait,
+		collection: &Collection{},
+	}
+}
+
+// Run executes the job until it is complete, fails or context is done, and
+// returns results collected so far. Failed or cancelled job continues where
+// it stopped on the next Run. Once the request limit is known, Run fails with
+// ErrReserveTooHigh when the next request and the reserve exceed it.
+func (s *Scheduler) Run(ctx context.Context) (*Collection, error) {
+	s.run.Lock()
+	defer s.run.Unlock()
+
+	s.mu.Lock()
+	s.started = time.Now()
+	s.mu.Unlock()
+
+	defer s.pauseClock()
+
+	for {
+		request, ok := s.request()
+		if !ok {
+			return s.result(), nil
+		}
+
+		if err := s.waitResumed(ctx); err != nil {
+			return s.result(), err
+		}
+
+		names := request.length()
+
+		if !s.fits(names) {
+			return s.result(), ErrReserveTooHigh
+		}
+
+		if d := s.client.quotaWait(names + int(s.reserve)); d > 0 {
+			if err := s.wait(ctx, d); err != nil {
+				return s.result(), err
+			}
+
+			continue
+		}
+
+		collection, err := s.client.Execute(request.withContext(ctx))
+		if err != nil {
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || !errors.Is(err, ErrTooManyRequests) || errors.Is(err, ErrRequestLimitTooLow) {
+				return s.result(), err
+			}
+
+			d := apiErr.RetryAfter
+			if d < s.minWait {
+				d = s.minWait
+			}
+
+			if err = s.wait(ctx, d); err != nil {
+				return s.result(), err
+			}
+
+			continue
+		}
+
+		s.complete(collection, names)
+	}
+}
+
+// Pause pauses the job before the next request.
+func (s *Scheduler) Pause() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.resumed == nil {
+		s.resumed = make(chan struct{})
+	}
+}
+
+// Resume resumes paused job.
+func (s *Scheduler) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.resumed != nil {
+		close(s.resumed)
+		s.resumed = nil
+	}
+}
+
+// Progress returns progress of the job.
+func (s *Scheduler) Progress() Progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := Progress{
+		Done:         s.done,
+		Total:        s.plan.Billed,
+		RequestsDone: s.next,
+		Requests:     len(s.plan.Requests),
+		Paused:       s.resumed != nil,
+		WaitingUntil: s.waitingUntil,
+	}
+
+	elapsed := s.elapsed
+	if !s.started.IsZero() {
+		elapsed += time.Since(s.started)
+	}
+
+	if s.done > 0 {
+		p.ETA = time.Duration(float64(elapsed) / float64(s.done) * float64(p.Total-p.Done))
+	}
+
+	return p
+}
+
+// fits reports whether names and the reserve fit in the time window, true
+// while the request limit is unknown.
+func (s *Scheduler) fits(names int) bool {
+	info := s.client.Info()
+	if info == nil || info.Unknown || info.Limit <= 0 {
+		return true
+	}
+
+	return int64(names)+s.reserve <= info.Limit
+}
+
+func (s *Scheduler) request() (*Request, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.next >= len(s.plan.Requests) {
+		return nil, false
+	}
+
+	return s.plan.Requests[s.next], true
+}
+
+func (s *Scheduler) complete(collection *Collection, names int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.collection.info = collection.info
+	s.collection.genders = append(s.collection.genders, collection.genders...)
+	s.done += int64(names)
+	s.next++
+}
+
+func (s *Scheduler) result() *Collection {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.collection.with(s.collection.Slice())
+}
+
+// pauseClock stops counting elapsed time when Run returns.
+func (s *Scheduler) pauseClock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.elapsed += time.Since(s.started)
+	s.started = time.Time{}
+}
+
+func (s *Scheduler) waitResumed(ctx context.Context) error {
+	s.mu.Lock()
+	resumed := s.resumed
+	s.mu.Unlock()
+
+	if resumed == nil {
+		return nil
+	}
+
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) wait(ctx context.Context, d time.Duration) error {
+	s.mu.Lock()
+	s.waitingUntil = time.Now().Add(d)
+	s.mu.Unlock()
+
+	s.client.logf("scheduler: waiting %s for the time window", d)
+
+	defer func() {
+		s.mu.Lock()
+		s.waitingUntil = time.Time{}
+		s.mu.Unlock()
+	}()
+
+	if !sleep(ctx, d) {
+		return ctx.Err()
+	}
+
+	return nil
+}
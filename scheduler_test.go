@@ -0,0 +1,245 @@
+package genderize_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexeyco/genderize"
+)
+
+const (
+	schedulerLimit  = 20
+	schedulerWindow = 20 * time.Millisecond
+)
+
+type testSchedulerAPI struct {
+	mu        sync.Mutex
+	opened    time.Time
+	remaining int
+	calls     int
+	rejected  int
+}
+
+func (a *testSchedulerAPI) handle(req *http.Request) (res *http.Response, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.calls++
+
+	if time.Since(a.opened) >= schedulerWindow {
+		a.opened = time.Now()
+		a.remaining = schedulerLimit
+	}
+
+	names := req.URL.Query()["name[]"]
+
+	h := http.Header{}
+	h.Set(genderize.HdrXRateLimitLimit, strconv.Itoa(schedulerLimit))
+	h.Set(genderize.HdrXRateReset, "1")
+
+	if len(names) > a.remaining {
+		a.rejected++
+
+		h.Set(genderize.HdrXRateLimitRemaining, strconv.Itoa(a.remaining))
+
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     h,
+			Body:       ioutil.NopCloser(strings.NewReader(`{"error":"Request limit reached"}`)),
+		}, nil
+	}
+
+	a.remaining -= len(names)
+	h.Set(genderize.HdrXRateLimitRemaining, strconv.Itoa(a.remaining))
+
+	genders := make([]*genderize.Gender, 0)
+	for _, name := range names {
+		genders = append(genders, &genderize.Gender{Name: name, Gender: genderize.Female, Probability: 0.9, Count: 10})
+	}
+
+	b, _ := json.Marshal(genders)
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     h,
+		Body:       ioutil.NopCloser(bytes.NewReader(b)),
+	}, nil
+}
+
+// testSchedulerClient returns client of the fake API whose reset header counts
+// time windows.
+func testSchedulerClient(api *testSchedulerAPI, options ...genderize.Option) *genderize.Client {
+	client := genderize.NewClient(append(options, genderize.WithHTTPClient(testClientClient(api.handle)))...)
+	genderize.SetResetUnit(client, schedulerWindow)
+
+	return client
+}
+
+type testSchedulerLogger func(message string)
+
+func (l testSchedulerLogger) Printf(format string, v ...interface{}) {
+	l(fmt.Sprintf(format, v...))
+}
+
+func TestScheduler_Run(t *testing.T) {
+	api := &testSchedulerAPI{}
+	client := testSchedulerClient(api)
+
+	s := client.Schedule(append(testPlanNames(30), "Name0"), 0)
+
+	c, err := s.Run(context.TODO())
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if c.Length() != 30 {
+		t.Errorf(`Should be %d, %d given`, 30, c.Length())
+	}
+
+	if c.AtX(29).Name != "Name29" {
+		t.Errorf(`Should be "%s", "%s" given`, "Name29", c.AtX(29).Name)
+	}
+
+	if api.rejected != 0 {
+		t.Errorf(`Should be %d, %d given`, 0, api.rejected)
+	}
+
+	p := s.Progress()
+	if p.Done != 30 || p.Total != 30 || p.RequestsDone != 3 || p.Requests != 3 || p.ETA != 0 {
+		t.Errorf(`Should be complete, %+v given`, p)
+	}
+}
+
+func TestScheduler_Run_TooManyRequests(t *testing.T) {
+	api := &testSchedulerAPI{
+		opened: time.Now(),
+	}
+
+	s := testSchedulerClient(api).Schedule(testPlanNames(5), 0)
+	genderize.SetSchedulerMinWait(s, schedulerWindow)
+
+	c, err := s.Run(context.TODO())
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if c.Length() != 5 {
+		t.Errorf(`Should be %d, %d given`, 5, c.Length())
+	}
+
+	if api.rejected != 1 {
+		t.Errorf(`Should be %d, %d given`, 1, api.rejected)
+	}
+}
+
+func TestScheduler_Run_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	// The job is cancelled as soon as it waits for the time window.
+	logger := testSchedulerLogger(func(message string) {
+		if strings.HasPrefix(message, "scheduler: waiting") {
+			cancel()
+		}
+	})
+
+	api := &testSchedulerAPI{}
+	s := testSchedulerClient(api, genderize.WithLogger(logger)).Schedule(testPlanNames(30), 5)
+
+	c, err := s.Run(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf(`Should be context.Canceled, "%v" given`, err)
+	}
+
+	// The first window allows 20 names, 5 of them are reserved.
+	if c.Length() != 10 {
+		t.Errorf(`Should be %d, %d given`, 10, c.Length())
+	}
+
+	if p := s.Progress(); p.Done != 10 || p.ETA == 0 {
+		t.Errorf(`Should be in progress, %+v given`, p)
+	}
+
+	c, err = s.Run(context.TODO())
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if c.Length() != 30 {
+		t.Errorf(`Should be %d, %d given`, 30, c.Length())
+	}
+}
+
+func TestScheduler_Run_ReserveTooHigh(t *testing.T) {
+	api := &testSchedulerAPI{}
+	client := testSchedulerClient(api)
+
+	// The limit is unknown until the first response, then 10 names and 15
+	// reserved exceed the limit of 20.
+	c, err := client.Schedule(testPlanNames(30), 15).Run(context.TODO())
+	if !errors.Is(err, genderize.ErrReserveTooHigh) {
+		t.Errorf(`Should be genderize.ErrReserveTooHigh, "%v" given`, err)
+	}
+
+	if c.Length() != 10 {
+		t.Errorf(`Should be %d, %d given`, 10, c.Length())
+	}
+
+	if api.calls != 1 {
+		t.Errorf(`Should be %d, %d given`, 1, api.calls)
+	}
+}
+
+func TestScheduler_Pause(t *testing.T) {
+	var resumed int32
+
+	api := &testSchedulerAPI{}
+	client := genderize.NewClient(genderize.WithHTTPClient(testClientClient(func(req *http.Request) (*http.Response, error) {
+		if atomic.LoadInt32(&resumed) == 0 {
+			t.Error(`Should not be called while paused`)
+		}
+
+		return api.handle(req)
+	})))
+
+	s := client.Schedule(testPlanNames(10), 0)
+	s.Pause()
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		if _, err := s.Run(context.TODO()); err != nil {
+			t.Errorf(`Should be nil, "%s" given`, err)
+		}
+	}()
+
+	if p := s.Progress(); !p.Paused || p.Done != 0 {
+		t.Errorf(`Should be paused, %+v given`, p)
+	}
+
+	atomic.StoreInt32(&resumed, 1)
+	s.Resume()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal(`Should be complete`)
+	}
+
+	if p := s.Progress(); p.Paused || p.Done != 10 {
+		t.Errorf(`Should be complete, %+v given`, p)
+	}
+}
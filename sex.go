@@ -0,0 +1,62 @@
+package genderize
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Sex of a name as reported by API.
+type Sex string
+
+const (
+	// Unknown API has no data about the name.
+	Unknown Sex = ""
+
+	// Male name.
+	Male Sex = "male"
+
+	// Female name.
+	Female Sex = "female"
+)
+
+// IsKnown reports whether API was able to determine the gender.
+func (s Sex) IsKnown() bool {
+	return s == Male || s == Female
+}
+
+// String returns "male", "female" or "unknown".
+func (s Sex) String() string {
+	if !s.IsKnown() {
+		return "unknown"
+	}
+
+	return string(s)
+}
+
+// MarshalJSON encodes unknown gender as null.
+func (s Sex) MarshalJSON() ([]byte, error) {
+	if !s.IsKnown() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON decodes gender, null stands for unknown.
+func (s *Sex) UnmarshalJSON(b []byte) (err error) {
+	var v *string
+	if err = json.Unmarshal(b, &v); err != nil {
+		return
+	}
+
+	switch {
+	case v == nil:
+		*s = Unknown
+	case Sex(*v).IsKnown():
+		*s = Sex(*v)
+	default:
+		err = fmt.Errorf(`%w: "%s"`, ErrGenderValue, *v)
+	}
+
+	return
+}
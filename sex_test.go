@@ -0,0 +1,85 @@
+package genderize_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+type sexTableRow struct {
+	json string
+	sex  genderize.Sex
+}
+
+func TestSex_IsKnown(t *testing.T) {
+	if !genderize.Male.IsKnown() {
+		t.Error(`Should be known`)
+	}
+
+	if !genderize.Female.IsKnown() {
+		t.Error(`Should be known`)
+	}
+
+	if genderize.Unknown.IsKnown() {
+		t.Error(`Should not be known`)
+	}
+}
+
+func TestSex_String(t *testing.T) {
+	if genderize.Unknown.String() != "unknown" {
+		t.Errorf(`Should be "%s", "%s" given`, "unknown", genderize.Unknown.String())
+	}
+
+	if genderize.Male.String() != "male" {
+		t.Errorf(`Should be "%s", "%s" given`, "male", genderize.Male.String())
+	}
+}
+
+func TestSex_MarshalJSON(t *testing.T) {
+	table := []sexTableRow{
+		{json: `"male"`, sex: genderize.Male},
+		{json: `"female"`, sex: genderize.Female},
+		{json: `null`, sex: genderize.Unknown},
+	}
+
+	for _, r := range table {
+		b, err := json.Marshal(r.sex)
+		if err != nil {
+			t.Errorf(`Should be nil, "%s" given`, err)
+		}
+
+		if string(b) != r.json {
+			t.Errorf(`Should be %s, %s given`, r.json, string(b))
+		}
+	}
+}
+
+func TestSex_UnmarshalJSON(t *testing.T) {
+	table := []sexTableRow{
+		{json: `"male"`, sex: genderize.Male},
+		{json: `"female"`, sex: genderize.Female},
+		{json: `null`, sex: genderize.Unknown},
+	}
+
+	for _, r := range table {
+		s := genderize.Female
+		if err := json.Unmarshal([]byte(r.json), &s); err != nil {
+			t.Errorf(`Should be nil, "%s" given`, err)
+		}
+
+		if s != r.sex {
+			t.Errorf(`Should be "%s", "%s" given`, r.sex, s)
+		}
+	}
+}
+
+func TestSex_UnmarshalJSON_Err(t *testing.T) {
+	var s genderize.Sex
+
+	err := json.Unmarshal([]byte(`"robot"`), &s)
+	if !errors.Is(err, genderize.ErrGenderValue) {
+		t.Errorf(`Should be genderize.ErrGenderValue, "%v" given`, err)
+	}
+}
@@ -0,0 +1,105 @@
+package genderize
+
+// Stats gender distribution statistics of a collection.
+type Stats struct {
+	// Total number of results.
+	Total int `json:"total"`
+
+	// Male number of results labeled as male.
+	Male int `json:"male"`
+
+	// Female number of results labeled as female.
+	Female int `json:"female"`
+
+	// Unknown number of results without gender data.
+	Unknown int `json:"unknown"`
+
+	// EvenSplit number of evenly split estimates, they have gender data but no
+	// label.
+	EvenSplit int `json:"even_split"`
+
+	// MaleShare share of male labels among known results.
+	MaleShare float64 `json:"male_share"`
+
+	// FemaleShare share of female labels among known results.
+	FemaleShare float64 `json:"female_share"`
+
+	// UnknownRate share of unknown results among all results.
+	UnknownRate float64 `json:"unknown_rate"`
+
+	// ExpectedMale probability-weighted number of males among results with
+	// gender data.
+	ExpectedMale float64 `json:"expected_male"`
+
+	// ExpectedFemale probability-weighted number of females among results with
+	// gender data.
+	ExpectedFemale float64 `json:"expected_female"`
+
+	// Variance of the expected numbers of males and females.
+	Variance float64 `json:"variance"`
+
+	// ByCountry statistics per country ID, empty ID stands for global results.
+	ByCountry map[string]*Stats `json:"by_country,omitempty"`
+}
+
+func (s *Stats) add(g *Gender) {
+	s.Total++
+
+	male, ok := g.male()
+	if !ok {
+		s.Unknown++
+
+		return
+	}
+
+	switch g.Gender {
+	case Male:
+		s.Male++
+	case Female:
+		s.Female++
+	default:
+		s.EvenSplit++
+	}
+
+	s.ExpectedMale += male
+	s.ExpectedFemale += 1 - male
+	s.Variance += male * (1 - male)
+}
+
+func (s *Stats) finish() {
+	if known := s.Male + s.Female; known != 0 {
+		s.MaleShare = float64(s.Male) / float64(known)
+		s.FemaleShare = float64(s.Female) / float64(known)
+	}
+
+	if s.Total != 0 {
+		s.UnknownRate = float64(s.Unknown) / float64(s.Total)
+	}
+}
+
+// Stats returns gender distribution statistics of the collection.
+func (c *Collection) Stats() *Stats {
+	stats := &Stats{
+		ByCountry: map[string]*Stats{},
+	}
+
+	for _, g := range c.genders {
+		stats.add(g)
+
+		country, ok := stats.ByCountry[g.CountryID]
+		if !ok {
+			country = &Stats{}
+			stats.ByCountry[g.CountryID] = country
+		}
+
+		country.add(g)
+	}
+
+	stats.finish()
+
+	for _, country := range stats.ByCountry {
+		country.finish()
+	}
+
+	return stats
+}
@@ -0,0 +1,80 @@
+package genderize_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+const statsDelta = 1e-9
+
+func TestCollection_Stats(t *testing.T) {
+	httpClient := testCollectionClient(
+		&genderize.Gender{Name: "Alice", Gender: genderize.Female, Probability: 0.9, Count: 100, CountryID: "US"},
+		&genderize.Gender{Name: "John", Gender: genderize.Male, Probability: 0.8, Count: 100, CountryID: "US"},
+		&genderize.Gender{Name: "Mario", Gender: genderize.Male, Probability: 1, Count: 100, CountryID: "IT"},
+		&genderize.Gender{Name: "Zyxw", CountryID: "IT"},
+	)
+
+	r := genderize.NewRequest(context.TODO()).
+		Name("Alice", "John", "Mario", "Zyxw")
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	s := c.Stats()
+
+	if s.Total != 4 || s.Male != 2 || s.Female != 1 || s.Unknown != 1 {
+		t.Errorf(`Should be 4/2/1/1, %d/%d/%d/%d given`, s.Total, s.Male, s.Female, s.Unknown)
+	}
+
+	if math.Abs(s.MaleShare-2.0/3) > statsDelta {
+		t.Errorf(`Should be %f, %f given`, 2.0/3, s.MaleShare)
+	}
+
+	if math.Abs(s.UnknownRate-0.25) > statsDelta {
+		t.Errorf(`Should be %f, %f given`, 0.25, s.UnknownRate)
+	}
+
+	if math.Abs(s.ExpectedMale-1.9) > statsDelta {
+		t.Errorf(`Should be %f, %f given`, 1.9, s.ExpectedMale)
+	}
+
+	if math.Abs(s.ExpectedFemale-1.1) > statsDelta {
+		t.Errorf(`Should be %f, %f given`, 1.1, s.ExpectedFemale)
+	}
+
+	if math.Abs(s.Variance-0.25) > statsDelta {
+		t.Errorf(`Should be %f, %f given`, 0.25, s.Variance)
+	}
+
+	if len(s.ByCountry) != 2 {
+		t.Errorf(`Should be %d, %d given`, 2, len(s.ByCountry))
+	}
+
+	if it := s.ByCountry["IT"]; it.Total != 2 || it.UnknownRate != 0.5 {
+		t.Errorf(`Should be 2/0.5, %d/%f given`, it.Total, it.UnknownRate)
+	}
+}
+
+func TestCollection_Stats_Empty(t *testing.T) {
+	httpClient := testCollectionClient()
+
+	// No names, so the response is empty.
+	r := genderize.NewRequest(context.TODO())
+
+	c, err := genderize.NewClient(genderize.WithHTTPClient(httpClient)).
+		Execute(r)
+	if err != nil {
+		t.Errorf(`Should be nil, "%s" given`, err)
+	}
+
+	if s := c.Stats(); s.Total != 0 || s.UnknownRate != 0 {
+		t.Error(`Should be empty`)
+	}
+}
@@ -0,0 +1,152 @@
+package genderize
+
+import (
+	"context"
+	"time"
+)
+
+const defaultStreamLinger = 100 * time.Millisecond
+
+// Result of the streamed name lookup.
+type Result struct {
+	Name   string
+	Gender *Gender
+	Err    error
+}
+
+// Stream consumes names from the channel, sends them in batches of up to
+// MaxNames names and emits per-name results. Batches respect rate limits of
+// the last API response and RequestInterval option. Output channel is closed
+// when the input one is closed and drained, or context is done.
+func (c *Client) Stream(ctx context.Context, names <-chan string) <-chan Result {
+	results := make(chan Result)
+
+	go func() {
+		defer close(results)
+
+		s := &stream{
+			client:  c,
+			ctx:     ctx,
+			results: results,
+		}
+
+		s.run(names)
+	}()
+
+	return results
+}
+
+type stream struct {
+	client  *Client
+	ctx     context.Context
+	results chan<- Result
+	last    time.Time
+}
+
+func (s *stream) run(names <-chan string) {
+	var (
+		batch  []string
+		linger *time.Timer
+		flush  <-chan time.Time
+	)
+
+	send := func() bool {
+		if linger != nil {
+			linger.Stop()
+		}
+
+		ok := s.send(batch)
+		batch, linger, flush = nil, nil, nil
+
+		return ok
+	}
+
+	defer func() {
+		if linger != nil {
+			linger.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-flush:
+			if !send() {
+				return
+			}
+		case name, ok := <-names:
+			if !ok {
+				if len(batch) != 0 {
+					send()
+				}
+
+				return
+			}
+
+			batch = append(batch, name)
+
+			if len(batch) == 1 {
+				linger = time.NewTimer(s.client.options.StreamLinger)
+				flush = linger.C
+			}
+
+			if len(batch) == MaxNames && !send() {
+				return
+			}
+		}
+	}
+}
+
+// send executes batch request and emits results, false is returned when
+// context is done.
+func (s *stream) send(batch []string) bool {
+	wait := s.client.quotaWait(len(batch))
+	if d := time.Until(s.last.Add(s.client.options.RequestInterval)); d > wait {
+		wait = d
+	}
+
+	if !sleep(s.ctx, wait) {
+		return false
+	}
+
+	s.last = time.Now()
+
+	collection, err := s.client.Execute(NewRequest(s.ctx).Name(batch...))
+
+	for i, name := range batch {
+		r := Result{
+			Name: name,
+			Err:  err,
+		}
+
+		if err == nil {
+			r.Gender, r.Err = collection.At(i)
+		}
+
+		select {
+		case s.results <- r:
+		case <-s.ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// sleep waits for the duration, false is returned when context is done.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
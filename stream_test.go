@@ -0,0 +1,155 @@
+package genderize_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexeyco/genderize"
+)
+
+func testStreamClient(calls *int32, remaining int64) *http.Client {
+	return testClientClient(func(req *http.Request) (res *http.Response, err error) {
+		atomic.AddInt32(calls, 1)
+
+		genders := make([]*genderize.Gender, 0)
+
+		for _, name := range req.URL.Query()["name[]"] {
+			genders = append(genders, &genderize.Gender{
+				Name:        name,
+				Gender:      genderize.Male,
+				Probability: 0.9,
+				Count:       100,
+			})
+		}
+
+		b, _ := json.Marshal(genders)
+
+		h := http.Header{}
+		h.Set(genderize.HdrXRateLimitLimit, "1000")
+		h.Set(genderize.HdrXRateLimitRemaining, strconv.FormatInt(remaining, 10))
+		h.Set(genderize.HdrXRateReset, "3600")
+
+		res = &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     h,
+			Body:       ioutil.NopCloser(bytes.NewReader(b)),
+		}
+
+		return
+	})
+}
+
+func TestClient_Stream(t *testing.T) {
+	var calls int32
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testStreamClient(&calls, 1000)),
+		genderize.WithStreamLinger(10*time.Millisecond),
+	)
+
+	names := make(chan string)
+
+	go func() {
+		defer close(names)
+
+		for i := 0; i < 25; i++ {
+			names <- "Name" + strconv.Itoa(i)
+		}
+	}()
+
+	cnt := 0
+
+	for r := range client.Stream(context.TODO(), names) {
+		if r.Err != nil {
+			t.Errorf(`Should be nil, "%s" given`, r.Err)
+
+			continue
+		}
+
+		should := "Name" + strconv.Itoa(cnt)
+		if r.Name != should || r.Gender.Name != should {
+			t.Errorf(`Should be "%s", "%s" given`, should, r.Name)
+		}
+
+		cnt++
+	}
+
+	if cnt != 25 {
+		t.Errorf(`Should be %d, %d given`, 25, cnt)
+	}
+
+	if atomic.LoadInt32(&calls) != 3 {
+		t.Errorf(`Should be %d, %d given`, 3, calls)
+	}
+}
+
+func TestClient_Stream_Linger(t *testing.T) {
+	var calls int32
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testStreamClient(&calls, 1000)),
+		genderize.WithStreamLinger(10*time.Millisecond),
+	)
+
+	names := make(chan string)
+	defer close(names)
+
+	results := client.Stream(context.TODO(), names)
+
+	names <- "Alice"
+
+	select {
+	case r := <-results:
+		if r.Name != "Alice" {
+			t.Errorf(`Should be "%s", "%s" given`, "Alice", r.Name)
+		}
+	case <-time.After(time.Second):
+		t.Error(`Incomplete batch should be sent`)
+	}
+}
+
+func TestClient_Stream_Cancel(t *testing.T) {
+	var calls int32
+
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testStreamClient(&calls, 0)),
+		genderize.WithStreamLinger(time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+
+	names := make(chan string, 2)
+	names <- "Alice"
+
+	results := client.Stream(ctx, names)
+
+	if r := <-results; r.Err != nil {
+		t.Errorf(`Should be nil, "%s" given`, r.Err)
+	}
+
+	// Quota is exhausted, so the next batch waits for the time window reset.
+	names <- "John"
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case _, ok := <-results:
+		if ok {
+			t.Error(`Should be closed`)
+		}
+	case <-time.After(time.Second):
+		t.Error(`Should be closed`)
+	}
+
+	if atomic.LoadInt32(&calls) != 1 {
+		t.Errorf(`Should be %d, %d given`, 1, calls)
+	}
+}
@@ -0,0 +1,55 @@
+package genderize
+
+import (
+	"context"
+	"sort"
+)
+
+// Distribution of the population by country ID, e.g. {"US": 0.6, "DE": 0.3,
+// "FR": 0.1}. Weights need not sum to one.
+type Distribution map[string]float64
+
+// countries returns country IDs with positive weights and their weights.
+func (d Distribution) countries() (countryIDs []string, weights []float64) {
+	for countryID, w := range d {
+		if w > 0 {
+			countryIDs = append(countryIDs, countryID)
+		}
+	}
+
+	sort.Strings(countryIDs)
+
+	weights = make([]float64, len(countryIDs))
+	for i, countryID := range countryIDs {
+		weights[i] = d[countryID]
+	}
+
+	return
+}
+
+// Weighted queries names in every country of the distribution and returns
+// mixture of the results weighted by the distribution. Countries without data
+// for a name are left out of its mixture. Empty distribution stands for the
+// global dataset.
+func (c *Client) Weighted(ctx context.Context, distribution Distribution, names ...string) (collection *Collection, err error) {
+	countryIDs, weights := distribution.countries()
+	if len(countryIDs) == 0 {
+		countryIDs, weights = []string{""}, []float64{1}
+	}
+
+	matrix, err := c.lookupCountries(ctx, names, countryIDs)
+	if err != nil {
+		return
+	}
+
+	collection = &Collection{
+		info:    c.Info(),
+		genders: make([]*Gender, len(names)),
+	}
+
+	for i, name := range names {
+		collection.genders[i] = combine(name, matrix[i], weights)
+	}
+
+	return
+}
@@ -0,0 +1,104 @@
+package genderize_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+
+	"github.com/alexeyco/genderize"
+)
+
+func TestClient_Weighted(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testConsensusClient()))
+
+	c, err := client.Weighted(context.TODO(), genderize.Distribution{
+		"IT": 0.25,
+		"DE": 0.75,
+		"FR": 0,
+	}, "Andrea", "Mario")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	// 0.25 * 0.98 + 0.75 * 0.1 = 0.32 of males.
+	andrea := c.FindX("Andrea")
+	if andrea.Gender != genderize.Female || math.Abs(andrea.Probability-0.68) > 1e-9 {
+		t.Errorf(`Should be female/0.68, %s/%f given`, andrea.Gender, andrea.Probability)
+	}
+
+	// DE has no data for Mario, so IT gets the whole weight.
+	mario := c.FindX("Mario")
+	if mario.Gender != genderize.Male || math.Abs(mario.Probability-0.99) > 1e-9 {
+		t.Errorf(`Should be male/0.99, %s/%f given`, mario.Gender, mario.Probability)
+	}
+
+	if c.Limit() != 1000 {
+		t.Errorf(`Should be %d, %d given`, 1000, c.Limit())
+	}
+}
+
+func TestClient_Weighted_Global(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testConsensusClient()))
+
+	c, err := client.Weighted(context.TODO(), nil, "Andrea")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if andrea := c.FirstX(); andrea.Gender != genderize.Female || math.Abs(andrea.Probability-0.6) > 1e-9 {
+		t.Errorf(`Should be female/0.6, %s/%f given`, andrea.Gender, andrea.Probability)
+	}
+}
+
+func TestClient_Weighted_EvenSplit(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testConsensusClient()))
+
+	c, err := client.Weighted(context.TODO(), genderize.Distribution{"IT": 1, "DE": 1}, "Sasha", "Mario")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if sasha := c.FindX("Sasha"); sasha.Gender.IsKnown() || !sasha.EvenSplit || sasha.Probability != 0.5 {
+		t.Errorf(`Should be even split, %+v given`, sasha)
+	}
+
+	if s := c.Stats(); s.Male != 1 || s.EvenSplit != 1 || s.Unknown != 0 || math.Abs(s.ExpectedMale-1.49) > 1e-9 {
+		t.Errorf(`Should be 1 male and 1 even split, %+v given`, s)
+	}
+
+	if u := c.Unknown(); u.Length() != 0 {
+		t.Errorf(`Should be %d, %d given`, 0, u.Length())
+	}
+
+	if class := (genderize.Policy{}).Classify(c.FindX("Sasha")); class != genderize.ClassUncertain {
+		t.Errorf(`Should be %s, %s given`, genderize.ClassUncertain, class)
+	}
+}
+
+func TestClient_Weighted_Err(t *testing.T) {
+	client := genderize.NewClient(genderize.WithHTTPClient(testClientClient(func(_ *http.Request) (*http.Response, error) {
+		return nil, testClientErr
+	})))
+
+	if _, err := client.Weighted(context.TODO(), genderize.Distribution{"US": 1}, "Andrea"); !errors.Is(err, testClientErr) {
+		t.Errorf(`Should be testClientErr, "%v" given`, err)
+	}
+}
+
+func TestClient_Weighted_Fallback(t *testing.T) {
+	client := genderize.NewClient(
+		genderize.WithHTTPClient(testConsensusClient()),
+		genderize.WithFallback(genderize.Policy{MinCount: 1000}),
+	)
+
+	c, err := client.Weighted(context.TODO(), genderize.Distribution{"DE": 1}, "Mario")
+	if err != nil {
+		t.Fatalf(`Should be nil, "%s" given`, err)
+	}
+
+	if mario := c.FirstX(); mario.Gender.IsKnown() || mario.Count != 0 {
+		t.Errorf(`Should be unknown, %+v given`, mario)
+	}
+}